@@ -4,6 +4,7 @@ import (
 	"context"
 	"log"
 	"mimiru-ai/controllers"
+	"mimiru-ai/domain/entities"
 	"mimiru-ai/domain/repositories"
 	"mimiru-ai/domain/services"
 	"mimiru-ai/infrastructure/cache"
@@ -23,20 +24,53 @@ import (
 type DIContainer struct {
 	db          *database.Client
 	cacheClient *cache.Client
+	decay       entities.DecayConfig
 
 	userRepo         repositories.UserRepository
 	userPrefRepo     repositories.UserPreferenceRepository
 	audioContentRepo repositories.AudioContentRepository
 	playbackRepo     repositories.PlaybackRepository
 	cacheRepo        repositories.CacheRepository
+	factorModelRepo  repositories.FactorModelRepository
+	dismissalRepo    repositories.DismissalRepository
+	ratingRepo       repositories.UserRatingRepository
 
 	algorithmService      *services.RecommendationAlgorithmService
+	sourceRegistry        *services.SourceRegistry
+	blender               *services.Blender
+	diversityReranker     *services.DiversityReranker
 	monitorService        *services.DatabaseMonitorService
 	recommendationUpdater *services.RecommendationUpdaterService
+	similarContentService *services.SimilarContentService
+	itemSimilarityService *services.ItemSimilarityService
+	popularityService     *services.PopularityService
+	factorizationService  *services.MatrixFactorizationService
+	coldStartService      *services.ColdStartService
+	continueListening     *services.ContinueListeningService
+	dismissalService      *services.DismissalService
+	trendingService       *services.TrendingService
+	sessionService        *services.SessionService
+	becauseYouListened    *services.BecauseYouListenedService
+	contextualBoost       *services.ContextualBoostService
+	homeShelves           []usecases.ShelfProviderInterface
 
 	getRecommendationsUC *usecases.GetRecommendationsUsecase
+	getBatchRecsUC       *usecases.GetBatchRecommendationsUsecase
+	trackEventUC         *usecases.TrackEventUsecase
+	trackEventsBulkUC    *usecases.TrackEventsBulkUsecase
+	getSimilarContentUC  *usecases.GetSimilarContentUsecase
+	updatePreferredCatUC *usecases.UpdatePreferredCategoriesUsecase
+	getContinueListenUC  *usecases.GetContinueListeningUsecase
+	recordDismissalUC    *usecases.RecordDismissalUsecase
+	getTrendingUC        *usecases.GetTrendingUsecase
+	getNextItemsUC       *usecases.GetNextItemsUsecase
+	buildQueueUC         *usecases.BuildQueueUsecase
+	getHomeFeedUC        *usecases.GetHomeFeedUsecase
 
 	recommendationController *controllers.RecommendationController
+	eventController          *controllers.EventController
+	contentController        *controllers.ContentController
+	userController           *controllers.UserController
 }
 
 func NewDIContainer() (*DIContainer, error) {
@@ -48,7 +82,9 @@ func NewDIContainer() (*DIContainer, error) {
 
 	container.initRepositories()
 
-	container.initDomainServices()
+	if err := container.initDomainServices(); err != nil {
+		return nil, err
+	}
 
 	container.initUsecases()
 
@@ -64,7 +100,13 @@ func (c *DIContainer) initInfrastructure() error {
 	}
 	c.db = db
 
+	// リクエストを受ける前にこのサービスが使うテーブルを作成
+	if err := db.Migrate(context.Background()); err != nil {
+		return err
+	}
+
 	c.cacheClient = cache.NewRedisClient()
+	c.decay = decayConfig()
 
 	return nil
 }
@@ -72,37 +114,216 @@ func (c *DIContainer) initInfrastructure() error {
 func (c *DIContainer) initRepositories() {
 	c.userRepo = infraRepos.NewUserRepositoryImpl(c.db)
 	c.userPrefRepo = infraRepos.NewUserPreferenceRepositoryImpl(c.db)
-	c.audioContentRepo = infraRepos.NewAudioContentRepositoryImpl(c.db)
+	c.audioContentRepo = infraRepos.NewAudioContentRepositoryImpl(c.db, c.decay)
 	c.playbackRepo = infraRepos.NewPlaybackRepositoryImpl(c.db)
 	c.cacheRepo = infraRepos.NewCacheRepositoryImpl(c.cacheClient)
+	c.factorModelRepo = infraRepos.NewFactorModelRepositoryImpl(modelDir())
+	c.dismissalRepo = infraRepos.NewDismissalRepositoryImpl(c.db)
+	c.ratingRepo = infraRepos.NewUserRatingRepositoryImpl(c.db)
 }
 
-func (c *DIContainer) initDomainServices() {
+func (c *DIContainer) initDomainServices() error {
+	c.itemSimilarityService = services.NewItemSimilarityService(c.playbackRepo, c.decay)
+	c.popularityService = services.NewPopularityService(c.audioContentRepo, entities.DefaultBayesianPopularityConfig())
+	c.factorizationService = services.NewMatrixFactorizationService(c.playbackRepo, c.factorModelRepo)
+
 	c.algorithmService = services.NewRecommendationAlgorithmService(
 		c.userRepo,
 		c.audioContentRepo,
 		c.playbackRepo,
 		c.userPrefRepo,
+		c.ratingRepo,
+		c.itemSimilarityService,
+		c.factorizationService,
+		c.popularityService,
+		c.decay,
 	)
 
 	c.monitorService = services.NewDatabaseMonitorService(c.db)
 	c.recommendationUpdater = services.NewRecommendationUpdaterService(c.cacheRepo)
+	c.similarContentService = services.NewSimilarContentService(c.audioContentRepo, c.playbackRepo)
+
+	c.sourceRegistry = services.NewSourceRegistry()
+	if err := c.algorithmService.RegisterSources(c.sourceRegistry); err != nil {
+		return err
+	}
+
+	coldStartConfig := services.DefaultColdStartConfig()
+	coldStartConfig.Decay = c.decay
+	c.coldStartService = services.NewColdStartService(
+		c.userRepo,
+		c.audioContentRepo,
+		c.playbackRepo,
+		coldStartConfig,
+	)
+	if err := c.sourceRegistry.Register(c.coldStartService); err != nil {
+		return err
+	}
+
+	c.dismissalService = services.NewDismissalService(c.dismissalRepo, c.audioContentRepo)
+	c.continueListening = services.NewContinueListeningService(c.audioContentRepo, c.playbackRepo, c.dismissalService)
+	if err := c.sourceRegistry.Register(c.continueListening); err != nil {
+		return err
+	}
+
+	c.trendingService = services.NewTrendingService(c.audioContentRepo, c.playbackRepo, services.DefaultTrendingConfig())
+	if err := c.sourceRegistry.Register(c.trendingService); err != nil {
+		return err
+	}
+
+	c.sessionService = services.NewSessionService(c.audioContentRepo, c.playbackRepo, services.DefaultSessionConfig())
+	if err := c.sourceRegistry.Register(c.sessionService); err != nil {
+		return err
+	}
+
+	c.becauseYouListened = services.NewBecauseYouListenedService(
+		c.audioContentRepo,
+		c.playbackRepo,
+		c.similarContentService,
+		c.dismissalService,
+		services.DefaultBecauseYouListenedConfig(),
+	)
+	if err := c.sourceRegistry.Register(c.becauseYouListened); err != nil {
+		return err
+	}
+
+	// ホームフィードの棚（この順に並べ、先の棚を優先して重複を除く）
+	c.homeShelves = []usecases.ShelfProviderInterface{
+		services.NewContinueListeningShelf(c.continueListening),
+		c.becauseYouListened,
+		services.NewTrendingInCategoriesShelf(c.trendingService, c.userRepo, c.userPrefRepo, c.playbackRepo, c.decay),
+		services.NewNewFromAuthorsShelf(c.audioContentRepo, c.playbackRepo, c.ratingRepo, c.decay),
+	}
+
+	c.contextualBoost = services.NewContextualBoostService(c.playbackRepo, c.audioContentRepo, services.DefaultContextualBoostConfig())
+
+	blender, err := services.NewBlender(c.sourceRegistry, services.DefaultBlendConfig())
+	if err != nil {
+		return err
+	}
+	blender.AddWeightModifier(c.coldStartService)
+	blender.AddFilter(c.dismissalService)
+	blender.AddAdjuster(c.dismissalService)
+	blender.AddAdjuster(c.contextualBoost)
+	c.blender = blender
+	c.diversityReranker = services.NewDiversityReranker(c.audioContentRepo, services.DefaultDiversityConfig())
+
+	return nil
 }
 
 func (c *DIContainer) initUsecases() {
 	c.getRecommendationsUC = usecases.NewGetRecommendationsUsecase(
-		c.algorithmService,
+		c.blender,
+		c.diversityReranker,
+		c.continueListening,
 		c.cacheRepo,
 		c.userRepo,
 	)
 
+	c.getBatchRecsUC = usecases.NewGetBatchRecommendationsUsecase(
+		c.getRecommendationsUC,
+		c.cacheRepo,
+	)
+	c.buildQueueUC = usecases.NewBuildQueueUsecase(
+		c.getRecommendationsUC,
+		c.audioContentRepo,
+		entities.DefaultQueueConfig(),
+	)
+
+	c.trackEventUC = usecases.NewTrackEventUsecase(
+		c.playbackRepo,
+		c.ratingRepo,
+		c.cacheRepo,
+	)
+
+	c.trackEventsBulkUC = usecases.NewTrackEventsBulkUsecase(
+		c.playbackRepo,
+		c.ratingRepo,
+		c.cacheRepo,
+	)
+
+	c.getSimilarContentUC = usecases.NewGetSimilarContentUsecase(
+		c.similarContentService,
+		c.audioContentRepo,
+		c.cacheRepo,
+	)
+
+	c.getTrendingUC = usecases.NewGetTrendingUsecase(c.trendingService, c.cacheRepo)
+	c.getNextItemsUC = usecases.NewGetNextItemsUsecase(c.sessionService, c.dismissalService)
+
+	c.updatePreferredCatUC = usecases.NewUpdatePreferredCategoriesUsecase(
+		c.userRepo,
+		c.cacheRepo,
+	)
+
+	c.getContinueListenUC = usecases.NewGetContinueListeningUsecase(
+		c.continueListening,
+		c.userRepo,
+	)
+
+	c.recordDismissalUC = usecases.NewRecordDismissalUsecase(
+		c.dismissalRepo,
+		c.userRepo,
+		c.cacheRepo,
+	)
+
+	c.getHomeFeedUC = usecases.NewGetHomeFeedUsecase(
+		c.homeShelves,
+		c.dismissalService,
+		c.audioContentRepo,
+		c.userRepo,
+	)
 }
 
 func (c *DIContainer) initControllers() {
 	c.recommendationController = controllers.NewRecommendationController(
 		c.getRecommendationsUC,
+		c.getBatchRecsUC,
+		c.buildQueueUC,
 		c.db,
 	)
+	c.eventController = controllers.NewEventController(
+		c.trackEventUC,
+		c.trackEventsBulkUC,
+	)
+	c.contentController = controllers.NewContentController(c.getSimilarContentUC, c.getTrendingUC, c.getNextItemsUC)
+	c.userController = controllers.NewUserController(
+		c.updatePreferredCatUC,
+		c.getContinueListenUC,
+		c.recordDismissalUC,
+		c.getHomeFeedUC,
+	)
+}
+
+func modelDir() string {
+	dir := os.Getenv("MODEL_DIR")
+	if dir == "" {
+		dir = "models"
+	}
+	return dir
+}
+
+// decayConfig 環境変数で指定された半減期（例: 168h）でデフォルトの減衰設定を上書き
+func decayConfig() entities.DecayConfig {
+	config := entities.DefaultDecayConfig()
+	halfLives := map[string]*time.Duration{
+		"DECAY_CONTENT_AGE_HALF_LIFE":        &config.ContentAgeHalfLife,
+		"DECAY_PLAY_RECENCY_HALF_LIFE":       &config.PlayRecencyHalfLife,
+		"DECAY_PREFERENCE_RECENCY_HALF_LIFE": &config.PreferenceRecencyHalfLife,
+	}
+	for name, halfLife := range halfLives {
+		value := os.Getenv(name)
+		if value == "" {
+			continue
+		}
+		parsed, err := time.ParseDuration(value)
+		if err != nil {
+			log.Printf("%s の形式が正しくないためデフォルト値を使用します: %v", name, err)
+			continue
+		}
+		*halfLife = parsed
+	}
+	return config
 }
 
 func (c *DIContainer) Close() {
@@ -114,6 +335,43 @@ func (c *DIContainer) Close() {
 	}
 }
 
+func registerRoutes(r *gin.Engine, c *DIContainer) {
+	r.GET("/health", c.recommendationController.HealthCheck)
+
+	// v1: ?user_id= 形式 + ResponseWidget
+	registerV1Routes(r.Group("/v1"), c)
+
+	// v2: README記載の /recommendations/:userId 形式
+	registerV2Routes(r.Group("/v2"), c)
+
+	// バージョンなし: 既存クライアント向けに両形式を併存
+	r.GET("/recommendations", c.recommendationController.GetRecommendations)
+	r.GET("/recommendations/:userId", c.recommendationController.GetUserRecommendations)
+	r.POST("/events", c.eventController.TrackEvent)
+	r.POST("/events/bulk", c.eventController.TrackEventsBulk)
+}
+
+func registerV1Routes(g *gin.RouterGroup, c *DIContainer) {
+	g.GET("/recommendations", c.recommendationController.GetRecommendations)
+	g.POST("/events", c.eventController.TrackEvent)
+	g.POST("/events/bulk", c.eventController.TrackEventsBulk)
+}
+
+func registerV2Routes(g *gin.RouterGroup, c *DIContainer) {
+	g.GET("/recommendations/:userId", c.recommendationController.GetUserRecommendations)
+	g.POST("/recommendations/batch", c.recommendationController.BatchGetRecommendations)
+	g.GET("/recommendations/:userId/queue", c.recommendationController.BuildQueue)
+	g.GET("/contents/:id/similar", c.contentController.GetSimilarContent)
+	g.GET("/trending", c.contentController.GetTrending)
+	g.GET("/next", c.contentController.GetNextItems)
+	g.PUT("/users/:userId/preferred-categories", c.userController.UpdatePreferredCategories)
+	g.GET("/users/:userId/continue-listening", c.userController.GetContinueListening)
+	g.GET("/users/:userId/home", c.userController.GetHomeFeed)
+	g.POST("/users/:userId/dismissals", c.userController.RecordDismissal)
+	g.POST("/events", c.eventController.TrackEvent)
+	g.POST("/events/bulk", c.eventController.TrackEventsBulk)
+}
+
 func main() {
 	if err := godotenv.Load(); err != nil {
 		// .envファイルが見つからないため、環境変数を使用
@@ -130,8 +388,7 @@ func main() {
 	r.Use(gin.Recovery())
 	r.Use(gin.Logger())
 
-	r.GET("/health", container.recommendationController.HealthCheck)
-	r.GET("/recommendations", container.recommendationController.GetRecommendations)
+	registerRoutes(r, container)
 
 	port := os.Getenv("PORT")
 	if port == "" {
@@ -150,6 +407,11 @@ func main() {
 		container.monitorService.PollingMonitor(monitorCtx, 30*time.Second)
 	}()
 
+	go container.itemSimilarityService.StartRebuilder(monitorCtx, time.Hour)
+	go container.popularityService.StartRebuilder(monitorCtx, 15*time.Minute)
+	go container.sessionService.StartRebuilder(monitorCtx, time.Hour)
+	go container.factorizationService.StartReloader(monitorCtx, 10*time.Minute)
+
 	go func() {
 		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
 			log.Fatalf("リッスンエラー: %s\n", err)
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"mimiru-ai/domain/services"
+	"mimiru-ai/infrastructure/database"
+	infraRepos "mimiru-ai/infrastructure/repositories"
+	"os"
+	"time"
+
+	"github.com/joho/godotenv"
+)
+
+// 潜在因子モデル（暗黙的フィードバックALS）をHTTPサーバー外で学習するコマンド
+func main() {
+	if err := godotenv.Load(); err != nil {
+		// .envファイルが見つからないため、環境変数を使用
+	}
+
+	defaults := services.DefaultALSConfig()
+	days := flag.Int("days", 180, "学習に使う再生履歴の期間（日）")
+	factors := flag.Int("factors", defaults.Factors, "潜在因子の次元数")
+	iterations := flag.Int("iterations", defaults.Iterations, "反復回数")
+	lambda := flag.Float64("lambda", defaults.Lambda, "L2正則化")
+	alpha := flag.Float64("alpha", defaults.Alpha, "信頼度係数")
+	playHalfLife := flag.Duration("play-half-life", defaults.Decay.PlayRecencyHalfLife, "再生の経過時間による減衰の半減期（0で減衰なし）")
+	flag.Parse()
+
+	// 0以下では学習が空回りするか、次元0のモデルが保存されてしまうため学習前に弾く
+	if *days <= 0 {
+		log.Fatalf("-days には1以上を指定してください: %d", *days)
+	}
+	if *factors <= 0 {
+		log.Fatalf("-factors には1以上を指定してください: %d", *factors)
+	}
+	if *iterations <= 0 {
+		log.Fatalf("-iterations には1以上を指定してください: %d", *iterations)
+	}
+
+	db, err := database.NewPostgresClient()
+	if err != nil {
+		log.Fatal("データベース接続に失敗しました:", err)
+	}
+	defer db.Close()
+
+	dir := os.Getenv("MODEL_DIR")
+	if dir == "" {
+		dir = "models"
+	}
+
+	service := services.NewMatrixFactorizationService(
+		infraRepos.NewPlaybackRepositoryImpl(db),
+		infraRepos.NewFactorModelRepositoryImpl(dir),
+	)
+
+	config := services.ALSConfig{
+		Factors:    *factors,
+		Iterations: *iterations,
+		Lambda:     *lambda,
+		Alpha:      *alpha,
+		Seed:       defaults.Seed,
+		Decay:      defaults.Decay,
+	}
+	config.Decay.PlayRecencyHalfLife = *playHalfLife
+
+	start := time.Now()
+	model, err := service.Train(context.Background(), *days, config)
+	if err != nil {
+		log.Fatal("学習に失敗しました:", err)
+	}
+
+	log.Printf("学習が完了しました: version=%s users=%d items=%d factors=%d elapsed=%s dir=%s",
+		model.Version, len(model.UserIDs), len(model.ItemIDs), model.Factors, time.Since(start), dir)
+}
@@ -83,6 +83,12 @@ var (
 	ErrInvalidUserIDFormat  = NewBadRequestError("ユーザーIDの形式が正しくありません")
 	ErrInvalidEventData     = NewBadRequestError("イベントデータが正しくありません")
 	ErrUserNotFound         = NewNotFoundError("ユーザーが見つかりません")
+	ErrInvalidContentID     = NewBadRequestError("コンテンツIDの形式が正しくありません")
+	ErrContentNotFound      = NewNotFoundError("コンテンツが見つかりません")
+	ErrInvalidCategoryIDs   = NewBadRequestError("カテゴリIDが正しくありません")
+	ErrInvalidDismissal     = NewBadRequestError("フィードバックの対象が正しくありません")
+	ErrInvalidContext       = NewBadRequestError("利用状況（時刻・タイムゾーン・端末）が正しくありません")
+	ErrInvalidQueueDuration = NewBadRequestError("目標時間が正しくありません")
 	ErrRecommendationFailed = NewInternalServerError("レコメンド取得に失敗しました")
 	ErrEventTrackingFailed  = NewInternalServerError("イベント追跡に失敗しました")
 	ErrDatabaseConnection   = NewServiceUnavailableError("データベース接続に失敗しました")
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"errors"
+	"mimiru-ai/common"
+	"mimiru-ai/usecases"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ContentController struct {
+	getSimilarContentUC *usecases.GetSimilarContentUsecase
+	getTrendingUC       *usecases.GetTrendingUsecase
+	getNextItemsUC      *usecases.GetNextItemsUsecase
+}
+
+func NewContentController(
+	getSimilarContentUC *usecases.GetSimilarContentUsecase,
+	getTrendingUC *usecases.GetTrendingUsecase,
+	getNextItemsUC *usecases.GetNextItemsUsecase,
+) *ContentController {
+	return &ContentController{
+		getSimilarContentUC: getSimilarContentUC,
+		getTrendingUC:       getTrendingUC,
+		getNextItemsUC:      getNextItemsUC,
+	}
+}
+
+// GetSimilarContent GET /contents/:id/similar
+func (c *ContentController) GetSimilarContent(ctx *gin.Context) {
+	contentID, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || contentID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidContentID)
+		return
+	}
+
+	output, err := c.getSimilarContentUC.Execute(ctx.Request.Context(), &usecases.GetSimilarContentInput{
+		ContentID: contentID,
+		Limit:     parseLimit(ctx, 10),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidContentID):
+			common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidContentID.Message, err.Error()))
+		case errors.Is(err, usecases.ErrContentNotFound):
+			common.RespondWithError(ctx, common.NewNotFoundError(common.ErrContentNotFound.Message, err.Error()))
+		default:
+			common.RespondWithError(ctx, common.NewInternalServerError("類似コンテンツの取得に失敗しました", err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+// GetTrending GET /trending
+func (c *ContentController) GetTrending(ctx *gin.Context) {
+	output, err := c.getTrendingUC.Execute(ctx.Request.Context(), &usecases.GetTrendingInput{
+		Limit: parseLimit(ctx, 20),
+	})
+	if err != nil {
+		common.RespondWithError(ctx, common.NewInternalServerError("急上昇コンテンツの取得に失敗しました", err.Error()))
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+// GetNextItems GET /next?userId=1&contentIds=1,2,3
+func (c *ContentController) GetNextItems(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Query("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	var sessionContentIDs []int
+	for _, idStr := range strings.Split(ctx.Query("contentIds"), ",") {
+		if idStr = strings.TrimSpace(idStr); idStr == "" {
+			continue
+		}
+		id, err := strconv.Atoi(idStr)
+		if err != nil || id <= 0 {
+			common.RespondWithError(ctx, common.ErrInvalidContentID)
+			return
+		}
+		sessionContentIDs = append(sessionContentIDs, id)
+	}
+
+	output, err := c.getNextItemsUC.Execute(ctx.Request.Context(), &usecases.GetNextItemsInput{
+		UserID:            userID,
+		SessionContentIDs: sessionContentIDs,
+		Limit:             parseLimit(ctx, 10),
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidUserID):
+			common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidUserIDFormat.Message, err.Error()))
+		case errors.Is(err, usecases.ErrInvalidContentID):
+			common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidContentID.Message, err.Error()))
+		default:
+			common.RespondWithError(ctx, common.NewInternalServerError("次に聴くコンテンツの取得に失敗しました", err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"mimiru-ai/common"
+	"mimiru-ai/usecases"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	maxBulkEventLines = 1000
+	maxBulkEventBytes = 4 << 20
+)
+
+type EventController struct {
+	trackEventUC      *usecases.TrackEventUsecase
+	trackEventsBulkUC *usecases.TrackEventsBulkUsecase
+}
+
+func NewEventController(
+	trackEventUC *usecases.TrackEventUsecase,
+	trackEventsBulkUC *usecases.TrackEventsBulkUsecase,
+) *EventController {
+	return &EventController{
+		trackEventUC:      trackEventUC,
+		trackEventsBulkUC: trackEventsBulkUC,
+	}
+}
+
+type trackEventRequest struct {
+	UserID         int    `json:"userId"`
+	AudioContentID int    `json:"audioContentId"`
+	EventType      string `json:"eventType"`
+	Duration       int    `json:"duration"`
+}
+
+type bulkEventLine struct {
+	EventID        string `json:"eventId"`
+	UserID         int    `json:"userId"`
+	AudioContentID int    `json:"audioContentId"`
+	EventType      string `json:"eventType"`
+	Duration       int    `json:"duration"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+func (c *EventController) TrackEvent(ctx *gin.Context) {
+	var req trackEventRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		appErr := common.NewBadRequestError(common.ErrInvalidEventData.Message, err.Error())
+		common.RespondWithError(ctx, appErr)
+		return
+	}
+
+	input := &usecases.TrackEventInput{
+		UserID:         req.UserID,
+		AudioContentID: req.AudioContentID,
+		EventType:      req.EventType,
+		Duration:       req.Duration,
+	}
+
+	output, err := c.trackEventUC.Execute(ctx.Request.Context(), input)
+	if err != nil {
+		if errors.Is(err, usecases.ErrInvalidEvent) {
+			appErr := common.NewBadRequestError(common.ErrInvalidEventData.Message, err.Error())
+			common.RespondWithError(ctx, appErr)
+			return
+		}
+		appErr := common.NewInternalServerError(common.ErrEventTrackingFailed.Message, err.Error())
+		common.RespondWithError(ctx, appErr)
+		return
+	}
+
+	common.RespondWithSuccess(ctx, output)
+}
+
+// TrackEventsBulk NDJSON形式（1行1イベント）の一括取り込み
+func (c *EventController) TrackEventsBulk(ctx *gin.Context) {
+	body := http.MaxBytesReader(ctx.Writer, ctx.Request.Body, maxBulkEventBytes)
+	scanner := bufio.NewScanner(body)
+
+	var items []*usecases.BulkEventItem
+	var parseErrors []*usecases.BulkEventResult
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		raw := bytes.TrimSpace(scanner.Bytes())
+		if len(raw) == 0 {
+			continue
+		}
+
+		if len(items)+len(parseErrors) >= maxBulkEventLines {
+			appErr := common.NewBadRequestError(
+				common.ErrInvalidEventData.Message,
+				fmt.Sprintf("1リクエストあたりのイベント数は%d件までです", maxBulkEventLines),
+			)
+			common.RespondWithError(ctx, appErr)
+			return
+		}
+
+		var line bulkEventLine
+		if err := json.Unmarshal(raw, &line); err != nil {
+			parseErrors = append(parseErrors, &usecases.BulkEventResult{
+				Line:   lineNo,
+				Status: usecases.BulkEventRejected,
+				Error:  err.Error(),
+			})
+			continue
+		}
+
+		var occurredAt time.Time
+		if line.Timestamp > 0 {
+			occurredAt = time.Unix(line.Timestamp, 0)
+		}
+
+		items = append(items, &usecases.BulkEventItem{
+			Line:    lineNo,
+			EventID: line.EventID,
+			Event: &usecases.TrackEventInput{
+				UserID:         line.UserID,
+				AudioContentID: line.AudioContentID,
+				EventType:      line.EventType,
+				Duration:       line.Duration,
+				OccurredAt:     occurredAt,
+			},
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		appErr := common.NewBadRequestError(common.ErrInvalidEventData.Message, err.Error())
+		common.RespondWithError(ctx, appErr)
+		return
+	}
+
+	if len(items) == 0 && len(parseErrors) == 0 {
+		appErr := common.NewBadRequestError(common.ErrInvalidEventData.Message, "イベントが含まれていません")
+		common.RespondWithError(ctx, appErr)
+		return
+	}
+
+	output := c.trackEventsBulkUC.Execute(ctx.Request.Context(), items)
+	for _, result := range parseErrors {
+		output.Add(result)
+	}
+	sort.Slice(output.Results, func(i, j int) bool {
+		return output.Results[i].Line < output.Results[j].Line
+	})
+
+	common.RespondWithSuccess(ctx, output)
+}
@@ -2,26 +2,35 @@ package controllers
 
 import (
 	"context"
+	"errors"
 	"mimiru-ai/common"
+	"mimiru-ai/domain/entities"
 	"mimiru-ai/infrastructure/database"
 	"mimiru-ai/usecases"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
 
 type RecommendationController struct {
-	getRecommendationsUC *usecases.GetRecommendationsUsecase
-	db                   *database.Client
+	getRecommendationsUC      *usecases.GetRecommendationsUsecase
+	getBatchRecommendationsUC *usecases.GetBatchRecommendationsUsecase
+	buildQueueUC              *usecases.BuildQueueUsecase
+	db                        *database.Client
 }
 
 func NewRecommendationController(
 	getRecommendationsUC *usecases.GetRecommendationsUsecase,
+	getBatchRecommendationsUC *usecases.GetBatchRecommendationsUsecase,
+	buildQueueUC *usecases.BuildQueueUsecase,
 	db *database.Client,
 ) *RecommendationController {
 	return &RecommendationController{
-		getRecommendationsUC: getRecommendationsUC,
-		db:                   db,
+		getRecommendationsUC:      getRecommendationsUC,
+		getBatchRecommendationsUC: getBatchRecommendationsUC,
+		buildQueueUC:              buildQueueUC,
+		db:                        db,
 	}
 }
 
@@ -39,17 +48,9 @@ func (c *RecommendationController) GetRecommendations(ctx *gin.Context) {
 		return
 	}
 
-	limitStr := ctx.Query("limit")
-	limit := 20
-	if limitStr != "" {
-		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
-			limit = parsedLimit
-		}
-	}
-
 	input := &usecases.GetRecommendationsInput{
 		UserID: userID,
-		Limit:  limit,
+		Limit:  parseLimit(ctx, 20),
 	}
 
 	output, err := c.getRecommendationsUC.Execute(ctx.Request.Context(), input)
@@ -62,6 +63,234 @@ func (c *RecommendationController) GetRecommendations(ctx *gin.Context) {
 	common.RespondWithSuccess(ctx, output)
 }
 
+// recommendationItemResponse README記載のレコメンド項目
+type recommendationItemResponse struct {
+	AudioContentID int                                `json:"audioContentId"`
+	Score          float64                            `json:"score"`
+	Reason         entities.RecommendationReason      `json:"reason"`
+	Reasons        []entities.RecommendationReason    `json:"reasons,omitempty"`
+	Explanation    *recommendationExplanationResponse `json:"explanation,omitempty"`
+}
+
+// recommendationExplanationResponse 説明モードで返す項目ごとの内訳
+type recommendationExplanationResponse struct {
+	Contributions []*sourceContributionResponse `json:"contributions"`
+	Adjustments   []*adjustmentResponse         `json:"adjustments"`
+}
+
+type sourceContributionResponse struct {
+	Source          string                        `json:"source"`
+	Reason          entities.RecommendationReason `json:"reason"`
+	RawScore        float64                       `json:"rawScore"`
+	NormalizedScore float64                       `json:"normalizedScore"`
+	Weight          float64                       `json:"weight"`
+	Score           float64                       `json:"score"`
+	SeedContentIDs  []int                         `json:"seedContentIds,omitempty"`
+	SimilarUserIDs  []int                         `json:"similarUserIds,omitempty"`
+}
+
+type adjustmentResponse struct {
+	Type   entities.AdjustmentType `json:"type"`
+	Detail string                  `json:"detail"`
+}
+
+// userRecommendationsResponse README記載のレスポンス形式（v2）
+type userRecommendationsResponse struct {
+	Recommendations []*recommendationItemResponse `json:"recommendations"`
+	UserID          int                           `json:"userId"`
+	Timestamp       int64                         `json:"timestamp"`
+}
+
+// GetUserRecommendations GET /recommendations/:userId（v2形式）
+func (c *RecommendationController) GetUserRecommendations(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	explain, _ := strconv.ParseBool(ctx.Query("explain"))
+	continueListening, _ := strconv.ParseBool(ctx.Query("continueListening"))
+	input := &usecases.GetRecommendationsInput{
+		UserID:                   userID,
+		Limit:                    parseLimit(ctx, 20),
+		Explain:                  explain,
+		IncludeContinueListening: continueListening,
+		Context:                  parseListeningContext(ctx),
+	}
+
+	output, err := c.getRecommendationsUC.Execute(ctx.Request.Context(), input)
+	if err != nil {
+		respondRecommendationError(ctx, err)
+		return
+	}
+
+	items := toRecommendationItems(output.Recommendations)
+	if explain {
+		for i, rec := range output.Recommendations {
+			items[i].Explanation = toExplanation(rec)
+		}
+	}
+
+	ctx.JSON(http.StatusOK, &userRecommendationsResponse{
+		Recommendations: items,
+		UserID:          output.UserID,
+		Timestamp:       output.Timestamp,
+	})
+}
+
+type batchRecommendationsRequest struct {
+	UserIDs []int `json:"userIds"`
+	Limit   int   `json:"limit"`
+}
+
+// batchUserRecommendationsResponse 一括取得のユーザーごとの結果（v2形式）
+type batchUserRecommendationsResponse struct {
+	UserID          int                           `json:"userId"`
+	Recommendations []*recommendationItemResponse `json:"recommendations"`
+	Error           string                        `json:"error,omitempty"`
+}
+
+// BatchGetRecommendations POST /recommendations/batch（v2形式）
+func (c *RecommendationController) BatchGetRecommendations(ctx *gin.Context) {
+	var req batchRecommendationsRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(ctx, common.NewBadRequestError("リクエストの形式が正しくありません", err.Error()))
+		return
+	}
+
+	output, err := c.getBatchRecommendationsUC.Execute(ctx.Request.Context(), &usecases.GetBatchRecommendationsInput{
+		UserIDs: req.UserIDs,
+		Limit:   req.Limit,
+	})
+	if err != nil {
+		respondRecommendationError(ctx, err)
+		return
+	}
+
+	results := make([]*batchUserRecommendationsResponse, 0, len(output.Results))
+	for _, result := range output.Results {
+		results = append(results, &batchUserRecommendationsResponse{
+			UserID:          result.UserID,
+			Recommendations: toRecommendationItems(result.Recommendations),
+			Error:           result.Error,
+		})
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{
+		"results":   results,
+		"timestamp": output.Timestamp,
+	})
+}
+
+// toRecommendationItems README形式のレコメンド項目に変換
+func toRecommendationItems(recs []*entities.Recommendation) []*recommendationItemResponse {
+	items := make([]*recommendationItemResponse, 0, len(recs))
+	for _, rec := range recs {
+		items = append(items, &recommendationItemResponse{
+			AudioContentID: rec.AudioContentID,
+			Score:          rec.Score,
+			Reason:         rec.Reason,
+			Reasons:        rec.AllReasons(),
+		})
+	}
+	return items
+}
+
+// toExplanation 説明モードの内訳に変換
+func toExplanation(rec *entities.Recommendation) *recommendationExplanationResponse {
+	explanation := &recommendationExplanationResponse{
+		Contributions: make([]*sourceContributionResponse, 0, len(rec.Contributions)),
+		Adjustments:   make([]*adjustmentResponse, 0, len(rec.Adjustments)),
+	}
+	for _, c := range rec.Contributions {
+		explanation.Contributions = append(explanation.Contributions, &sourceContributionResponse{
+			Source:          c.Source,
+			Reason:          c.Reason,
+			RawScore:        c.RawScore,
+			NormalizedScore: c.NormalizedScore,
+			Weight:          c.Weight,
+			Score:           c.Score,
+			SeedContentIDs:  c.SeedContentIDs,
+			SimilarUserIDs:  c.SimilarUserIDs,
+		})
+	}
+	for _, a := range rec.Adjustments {
+		explanation.Adjustments = append(explanation.Adjustments, &adjustmentResponse{
+			Type:   a.Type,
+			Detail: a.Detail,
+		})
+	}
+	return explanation
+}
+
+// BuildQueue GET /recommendations/:userId/queue?minutes=30（v2形式）
+func (c *RecommendationController) BuildQueue(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	minutes, err := strconv.Atoi(ctx.Query("minutes"))
+	if err != nil {
+		common.RespondWithError(ctx, common.ErrInvalidQueueDuration)
+		return
+	}
+
+	output, err := c.buildQueueUC.Execute(ctx.Request.Context(), &usecases.BuildQueueInput{
+		UserID:        userID,
+		TargetMinutes: minutes,
+		Context:       parseListeningContext(ctx),
+	})
+	if err != nil {
+		respondRecommendationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+// respondRecommendationError ユースケースのエラーをHTTPエラーに変換
+func respondRecommendationError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, usecases.ErrInvalidUserID):
+		common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidUserIDFormat.Message, err.Error()))
+	case errors.Is(err, usecases.ErrUserNotFound):
+		common.RespondWithError(ctx, common.NewNotFoundError(common.ErrUserNotFound.Message, err.Error()))
+	case errors.Is(err, usecases.ErrInvalidListeningContext):
+		common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidContext.Message, err.Error()))
+	case errors.Is(err, usecases.ErrInvalidQueueDuration):
+		common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidQueueDuration.Message, err.Error()))
+	default:
+		common.RespondWithError(ctx, common.NewInternalServerError(common.ErrRecommendationFailed.Message, err.Error()))
+	}
+}
+
+// parseListeningContext クエリパラメータ localTime・timezone・device を取得（いずれもなければnil）
+func parseListeningContext(ctx *gin.Context) *usecases.ListeningContextInput {
+	input := &usecases.ListeningContextInput{
+		LocalTime: ctx.Query("localTime"),
+		Timezone:  ctx.Query("timezone"),
+		Device:    ctx.Query("device"),
+	}
+	if input.LocalTime == "" && input.Timezone == "" && input.Device == "" {
+		return nil
+	}
+	return input
+}
+
+// parseLimit クエリパラメータlimitを取得（不正値はデフォルト、上限は usecases.MaxRecommendationLimit）
+func parseLimit(ctx *gin.Context, defaultLimit int) int {
+	limitStr := ctx.Query("limit")
+	if limitStr != "" {
+		if parsedLimit, err := strconv.Atoi(limitStr); err == nil && parsedLimit > 0 {
+			return min(parsedLimit, usecases.MaxRecommendationLimit)
+		}
+	}
+	return defaultLimit
+}
+
 
 func (c *RecommendationController) HealthCheck(ctx *gin.Context) {
 	if err := c.db.Pool.Ping(context.Background()); err != nil {
@@ -77,4 +306,4 @@ func (c *RecommendationController) HealthCheck(ctx *gin.Context) {
 	}
 
 	common.RespondWithSuccess(ctx, healthData)
-}
\ No newline at end of file
+}
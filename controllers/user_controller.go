@@ -0,0 +1,148 @@
+package controllers
+
+import (
+	"errors"
+	"mimiru-ai/common"
+	"mimiru-ai/usecases"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type UserController struct {
+	updatePreferredCategoriesUC *usecases.UpdatePreferredCategoriesUsecase
+	getContinueListeningUC      *usecases.GetContinueListeningUsecase
+	recordDismissalUC           *usecases.RecordDismissalUsecase
+	getHomeFeedUC               *usecases.GetHomeFeedUsecase
+}
+
+func NewUserController(
+	updatePreferredCategoriesUC *usecases.UpdatePreferredCategoriesUsecase,
+	getContinueListeningUC *usecases.GetContinueListeningUsecase,
+	recordDismissalUC *usecases.RecordDismissalUsecase,
+	getHomeFeedUC *usecases.GetHomeFeedUsecase,
+) *UserController {
+	return &UserController{
+		updatePreferredCategoriesUC: updatePreferredCategoriesUC,
+		getContinueListeningUC:      getContinueListeningUC,
+		recordDismissalUC:           recordDismissalUC,
+		getHomeFeedUC:               getHomeFeedUC,
+	}
+}
+
+type preferredCategoriesRequest struct {
+	CategoryIDs []int `json:"categoryIds"`
+}
+
+// UpdatePreferredCategories PUT /users/:userId/preferred-categories
+func (c *UserController) UpdatePreferredCategories(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	var req preferredCategoriesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(ctx, common.NewBadRequestError("リクエストの形式が正しくありません", err.Error()))
+		return
+	}
+
+	output, err := c.updatePreferredCategoriesUC.Execute(ctx.Request.Context(), &usecases.UpdatePreferredCategoriesInput{
+		UserID:      userID,
+		CategoryIDs: req.CategoryIDs,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidCategoryID):
+			common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidCategoryIDs.Message, err.Error()))
+		case errors.Is(err, usecases.ErrUserNotFound):
+			common.RespondWithError(ctx, common.NewNotFoundError(common.ErrUserNotFound.Message, err.Error()))
+		default:
+			common.RespondWithError(ctx, common.NewInternalServerError("好みのカテゴリの保存に失敗しました", err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+// GetContinueListening GET /users/:userId/continue-listening
+func (c *UserController) GetContinueListening(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	output, err := c.getContinueListeningUC.Execute(ctx.Request.Context(), &usecases.GetContinueListeningInput{
+		UserID: userID,
+		Limit:  parseLimit(ctx, 10),
+	})
+	if err != nil {
+		respondRecommendationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+// GetHomeFeed GET /users/:userId/home
+func (c *UserController) GetHomeFeed(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	output, err := c.getHomeFeedUC.Execute(ctx.Request.Context(), &usecases.GetHomeFeedInput{
+		UserID:        userID,
+		ItemsPerShelf: parseLimit(ctx, 10),
+	})
+	if err != nil {
+		respondRecommendationError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, output)
+}
+
+type dismissalRequest struct {
+	TargetType string `json:"targetType"`
+	TargetID   int    `json:"targetId"`
+}
+
+// RecordDismissal POST /users/:userId/dismissals
+func (c *UserController) RecordDismissal(ctx *gin.Context) {
+	userID, err := strconv.Atoi(ctx.Param("userId"))
+	if err != nil || userID <= 0 {
+		common.RespondWithError(ctx, common.ErrInvalidUserIDFormat)
+		return
+	}
+
+	var req dismissalRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		common.RespondWithError(ctx, common.NewBadRequestError("リクエストの形式が正しくありません", err.Error()))
+		return
+	}
+
+	output, err := c.recordDismissalUC.Execute(ctx.Request.Context(), &usecases.RecordDismissalInput{
+		UserID:     userID,
+		TargetType: req.TargetType,
+		TargetID:   req.TargetID,
+	})
+	if err != nil {
+		switch {
+		case errors.Is(err, usecases.ErrInvalidDismissal):
+			common.RespondWithError(ctx, common.NewBadRequestError(common.ErrInvalidDismissal.Message, err.Error()))
+		case errors.Is(err, usecases.ErrUserNotFound):
+			common.RespondWithError(ctx, common.NewNotFoundError(common.ErrUserNotFound.Message, err.Error()))
+		default:
+			common.RespondWithError(ctx, common.NewInternalServerError("フィードバックの保存に失敗しました", err.Error()))
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, output)
+}
@@ -25,21 +25,21 @@ func (ac *AudioContent) IsPopular() bool {
 	return ac.PlayCount > 100 || ac.LikeCount > 50
 }
 
-// IsNew 新しいコンテンツかどうかを判定
-func (ac *AudioContent) IsNew() bool {
-	return time.Since(ac.CreatedAt) <= 7*24*time.Hour // 7日以内
+// freshnessBonus 公開直後のコンテンツに加える人気度スコア（鮮度に応じて減衰）
+const freshnessBonus = 10.0
+
+// Freshness 公開からの経過時間による鮮度（公開直後が1.0、半減期ごとに半分）
+func (ac *AudioContent) Freshness(decay DecayConfig, now time.Time) float64 {
+	return decay.ContentFreshness(ac.CreatedAt, now)
 }
 
 // CalculatePopularityScore 人気度スコアを計算
-func (ac *AudioContent) CalculatePopularityScore() float64 {
+func (ac *AudioContent) CalculatePopularityScore(decay DecayConfig, now time.Time) float64 {
 	playScore := float64(ac.PlayCount) * 0.7
 	likeScore := float64(ac.LikeCount) * 1.5
 	
-	// 新しいコンテンツには追加のスコア
-	var recencyBonus float64
-	if ac.IsNew() {
-		recencyBonus = 10.0
-	}
+	// 新しいコンテンツほど追加のスコア
+	recencyBonus := freshnessBonus * ac.Freshness(decay, now)
 	
 	return playScore + likeScore + recencyBonus
 }
\ No newline at end of file
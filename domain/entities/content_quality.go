@@ -0,0 +1,73 @@
+package entities
+
+import "sort"
+
+// ContentEngagementStats コンテンツごとの再生・いいね・完了の集計
+type ContentEngagementStats struct {
+	AudioContentID int
+	Plays          int
+	Likes          int
+	Completions    int // 完了または FullListenRatio 以上聴いた再生
+}
+
+// BayesianPopularityConfig 人気度のベイズ平均の設定
+type BayesianPopularityConfig struct {
+	PriorWeight      float64 // 事前分布（全体平均）の重み（再生数換算）
+	LikeWeight       float64 // いいね率の寄与
+	CompletionWeight float64 // 完了率の寄与
+}
+
+// DefaultBayesianPopularityConfig デフォルト設定
+func DefaultBayesianPopularityConfig() BayesianPopularityConfig {
+	return BayesianPopularityConfig{
+		PriorWeight:      50,
+		LikeWeight:       0.5,
+		CompletionWeight: 0.5,
+	}
+}
+
+// BayesianAverage 事前平均 priorMean を priorWeight 回分の観測として加えた成功率
+// 試行の少ないコンテンツほど事前平均に近づく
+func BayesianAverage(successes, trials int, priorMean, priorWeight float64) float64 {
+	denominator := float64(trials) + priorWeight
+	if denominator <= 0 {
+		return priorMean
+	}
+	return (float64(successes) + priorMean*priorWeight) / denominator
+}
+
+// RankByBayesianPopularity 全体のいいね率・完了率を事前分布として各コンテンツの品質スコアを計算し、高い順に並べる
+// スコアは平滑化したいいね率・完了率を全体平均に対する倍率にして重み付けした和（平均的なコンテンツで1.0）
+func RankByBayesianPopularity(stats []*ContentEngagementStats, config BayesianPopularityConfig) []*ScoredItem {
+	var plays, likes, completions int
+	for _, s := range stats {
+		plays += s.Plays
+		likes += s.Likes
+		completions += s.Completions
+	}
+	if plays == 0 {
+		return []*ScoredItem{}
+	}
+	globalLikeRate := float64(likes) / float64(plays)
+	globalCompletionRate := float64(completions) / float64(plays)
+
+	items := make([]*ScoredItem, 0, len(stats))
+	for _, s := range stats {
+		var score float64
+		if globalLikeRate > 0 {
+			score += config.LikeWeight * BayesianAverage(s.Likes, s.Plays, globalLikeRate, config.PriorWeight) / globalLikeRate
+		}
+		if globalCompletionRate > 0 {
+			score += config.CompletionWeight * BayesianAverage(s.Completions, s.Plays, globalCompletionRate, config.PriorWeight) / globalCompletionRate
+		}
+		items = append(items, &ScoredItem{AudioContentID: s.AudioContentID, Score: score})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].AudioContentID < items[j].AudioContentID
+	})
+	return items
+}
@@ -0,0 +1,61 @@
+package entities
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBayesianAverage(t *testing.T) {
+	tests := []struct {
+		name        string
+		successes   int
+		trials      int
+		priorMean   float64
+		priorWeight float64
+		expected    float64
+	}{
+		{name: "観測なしは事前平均", successes: 0, trials: 0, priorMean: 0.1, priorWeight: 10, expected: 0.1},
+		{name: "少数の観測は事前平均に寄る", successes: 1, trials: 1, priorMean: 0.1, priorWeight: 9, expected: 0.19},
+		{name: "事前分布なし", successes: 3, trials: 10, priorMean: 0.1, priorWeight: 0, expected: 0.3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := BayesianAverage(tt.successes, tt.trials, tt.priorMean, tt.priorWeight)
+			if math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("%vを期待しましたが、%vを取得しました", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestRankByBayesianPopularity(t *testing.T) {
+	stats := []*ContentEngagementStats{
+		{AudioContentID: 1, Plays: 10000, Likes: 300, Completions: 3000}, // 再生数は多いが平均以下
+		{AudioContentID: 2, Plays: 500, Likes: 60, Completions: 400},     // 再生数は少ないが高評価
+		{AudioContentID: 3, Plays: 2, Likes: 2, Completions: 2},          // 観測が少なすぎる
+	}
+
+	ranked := RankByBayesianPopularity(stats, DefaultBayesianPopularityConfig())
+
+	expectedIDs := []int{2, 3, 1}
+	if len(ranked) != len(expectedIDs) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expectedIDs), len(ranked))
+	}
+	for i, item := range ranked {
+		if item.AudioContentID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], item.AudioContentID)
+		}
+	}
+
+	// 観測の少ないコンテンツは全体平均（1.0）に引き寄せられる（平滑化なしでは約16）
+	if ranked[1].Score > 2.0 {
+		t.Errorf("観測の少ないコンテンツのスコアは2.0以下を期待しましたが、%vを取得しました", ranked[1].Score)
+	}
+}
+
+func TestRankByBayesianPopularity_NoPlays(t *testing.T) {
+	if ranked := RankByBayesianPopularity(nil, DefaultBayesianPopularityConfig()); len(ranked) != 0 {
+		t.Errorf("空の結果を期待しましたが、%d件を取得しました", len(ranked))
+	}
+}
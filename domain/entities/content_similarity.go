@@ -0,0 +1,24 @@
+package entities
+
+// CoListenedContent 同じユーザーに聴かれたコンテンツ（共聴）
+type CoListenedContent struct {
+	AudioContentID int
+	ListenerCount  int // 両方を聴いたユーザー数
+}
+
+// SimilarContent シードに類似したコンテンツ
+type SimilarContent struct {
+	Content *AudioContent
+	Score   float64
+	Reasons []RecommendationReason
+}
+
+// HasReason 指定した理由を含むかチェック
+func (sc *SimilarContent) HasReason(reason RecommendationReason) bool {
+	for _, r := range sc.Reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,73 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// 途中まで聴いたとみなす進捗の範囲（これ未満は試し聴き、これ以上は聴き終わり）
+const (
+	ContinueListeningMinProgress = 0.05
+	ContinueListeningMaxProgress = 0.95
+)
+
+// ContinueListeningItem 途中まで聴いたコンテンツ
+type ContinueListeningItem struct {
+	Content       *AudioContent
+	PlayedSeconds int       // 再生位置の推定（秒）
+	Progress      float64   // 再生位置 / コンテンツの長さ
+	LastPlayedAt  time.Time // 最後に再生した日時
+}
+
+// BuildContinueListening 再生履歴から途中まで聴いたコンテンツを最近再生した順に抽出
+// 各再生記録の再生時間の最大値を再生位置とみなし、一度でも完了したコンテンツは除く
+func BuildContinueListening(playbacks []*PlaybackHistory, contents map[int]*AudioContent) []*ContinueListeningItem {
+	completed := make(map[int]bool)
+	items := make(map[int]*ContinueListeningItem)
+
+	for _, playback := range playbacks {
+		if playback.Completed {
+			completed[playback.AudioContentID] = true
+			continue
+		}
+
+		content, ok := contents[playback.AudioContentID]
+		if !ok || content.Duration <= 0 {
+			continue
+		}
+
+		item, ok := items[playback.AudioContentID]
+		if !ok {
+			item = &ContinueListeningItem{Content: content}
+			items[playback.AudioContentID] = item
+		}
+		if playback.Duration > item.PlayedSeconds {
+			item.PlayedSeconds = playback.Duration
+		}
+		if playback.PlayedAt.After(item.LastPlayedAt) {
+			item.LastPlayedAt = playback.PlayedAt
+		}
+	}
+
+	var result []*ContinueListeningItem
+	for contentID, item := range items {
+		if completed[contentID] {
+			continue
+		}
+
+		item.Progress = float64(item.PlayedSeconds) / float64(item.Content.Duration)
+		if item.Progress < ContinueListeningMinProgress || item.Progress >= ContinueListeningMaxProgress {
+			continue
+		}
+		result = append(result, item)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if !result[i].LastPlayedAt.Equal(result[j].LastPlayedAt) {
+			return result[i].LastPlayedAt.After(result[j].LastPlayedAt)
+		}
+		return result[i].Content.ID < result[j].Content.ID
+	})
+
+	return result
+}
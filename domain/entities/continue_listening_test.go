@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildContinueListening(t *testing.T) {
+	now := time.Now()
+	contents := map[int]*AudioContent{
+		1: {ID: 1, Duration: 600},
+		2: {ID: 2, Duration: 600},
+		3: {ID: 3, Duration: 600},
+		4: {ID: 4, Duration: 600},
+		5: {ID: 5, Duration: 600},
+	}
+	playbacks := []*PlaybackHistory{
+		{UserID: 1, AudioContentID: 1, PlayedAt: now.Add(-3 * time.Hour), Duration: 120},
+		{UserID: 1, AudioContentID: 1, PlayedAt: now.Add(-2 * time.Hour), Duration: 300}, // 再生位置は最大値
+		{UserID: 1, AudioContentID: 2, PlayedAt: now.Add(-1 * time.Hour), Duration: 60},
+		{UserID: 1, AudioContentID: 3, PlayedAt: now.Add(-5 * time.Hour), Duration: 200},
+		{UserID: 1, AudioContentID: 3, PlayedAt: now.Add(-4 * time.Hour), Duration: 600, Completed: true}, // 完了済み
+		{UserID: 1, AudioContentID: 4, PlayedAt: now, Duration: 10},                                       // 試し聴き
+		{UserID: 1, AudioContentID: 5, PlayedAt: now, Duration: 590},                                      // ほぼ聴き終わり
+		{UserID: 1, AudioContentID: 6, PlayedAt: now, Duration: 100},                                      // メタデータなし
+	}
+
+	items := BuildContinueListening(playbacks, contents)
+
+	expected := []int{2, 1}
+	if len(items) != len(expected) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expected), len(items))
+	}
+	for i, item := range items {
+		if item.Content.ID != expected[i] {
+			t.Errorf("インデックス %d: コンテンツ %d を期待しましたが、%d を取得しました", i, expected[i], item.Content.ID)
+		}
+	}
+
+	if items[1].PlayedSeconds != 300 || items[1].Progress != 0.5 {
+		t.Errorf("再生位置 300秒・進捗 0.5 を期待しましたが、%d秒・%v を取得しました", items[1].PlayedSeconds, items[1].Progress)
+	}
+}
@@ -0,0 +1,97 @@
+package entities
+
+import "time"
+
+// DismissalTargetType 「興味なし」の対象の種別
+type DismissalTargetType string
+
+const (
+	DismissalTargetContent  DismissalTargetType = "content"
+	DismissalTargetAuthor   DismissalTargetType = "author"
+	DismissalTargetCategory DismissalTargetType = "category"
+)
+
+// IsValid 既知の対象種別かどうか判定
+func (t DismissalTargetType) IsValid() bool {
+	switch t {
+	case DismissalTargetContent, DismissalTargetAuthor, DismissalTargetCategory:
+		return true
+	}
+	return false
+}
+
+// Dismissal ユーザーの「興味なし」フィードバック
+type Dismissal struct {
+	UserID     int
+	TargetType DismissalTargetType
+	TargetID   int
+	CreatedAt  time.Time
+}
+
+// IsValid フィードバックの妥当性をチェック
+func (d *Dismissal) IsValid() bool {
+	return d.UserID > 0 && d.TargetType.IsValid() && d.TargetID > 0
+}
+
+// DismissalSet ユーザーが興味なしにした対象の集合
+type DismissalSet struct {
+	contents   map[int]bool
+	authors    map[int]bool
+	categories map[int]bool
+}
+
+// NewDismissalSet フィードバックから集合を作成
+func NewDismissalSet(dismissals []*Dismissal) *DismissalSet {
+	set := &DismissalSet{
+		contents:   make(map[int]bool),
+		authors:    make(map[int]bool),
+		categories: make(map[int]bool),
+	}
+	for _, d := range dismissals {
+		switch d.TargetType {
+		case DismissalTargetContent:
+			set.contents[d.TargetID] = true
+		case DismissalTargetAuthor:
+			set.authors[d.TargetID] = true
+		case DismissalTargetCategory:
+			set.categories[d.TargetID] = true
+		}
+	}
+	return set
+}
+
+// IsEmpty フィードバックがないかどうか（nilも空とみなす）
+func (s *DismissalSet) IsEmpty() bool {
+	return s == nil || len(s.contents)+len(s.authors)+len(s.categories) == 0
+}
+
+// HasContentOnly コンテンツの指定のみで作者・カテゴリの判定が不要かどうか
+func (s *DismissalSet) HasContentOnly() bool {
+	return s.IsEmpty() || len(s.authors)+len(s.categories) == 0
+}
+
+// HasSuppressions 除外するコンテンツ・作者の指定があるかどうか
+func (s *DismissalSet) HasSuppressions() bool {
+	return !s.IsEmpty() && len(s.contents)+len(s.authors) > 0
+}
+
+// HasCategories 減点するカテゴリの指定があるかどうか
+func (s *DismissalSet) HasCategories() bool {
+	return !s.IsEmpty() && len(s.categories) > 0
+}
+
+// Suppresses 推薦しないコンテンツかどうか（コンテンツ・作者の指定。contentがnilならIDのみで判定）
+func (s *DismissalSet) Suppresses(contentID int, content *AudioContent) bool {
+	if s.IsEmpty() {
+		return false
+	}
+	if s.contents[contentID] {
+		return true
+	}
+	return content != nil && s.authors[content.AuthorID]
+}
+
+// DismissedCategory 興味なしにしたカテゴリのコンテンツかどうか
+func (s *DismissalSet) DismissedCategory(content *AudioContent) bool {
+	return !s.IsEmpty() && content != nil && s.categories[content.CategoryID]
+}
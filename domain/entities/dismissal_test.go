@@ -0,0 +1,40 @@
+package entities
+
+import "testing"
+
+func TestDismissalSet(t *testing.T) {
+	set := NewDismissalSet([]*Dismissal{
+		{UserID: 1, TargetType: DismissalTargetContent, TargetID: 1},
+		{UserID: 1, TargetType: DismissalTargetAuthor, TargetID: 100},
+		{UserID: 1, TargetType: DismissalTargetCategory, TargetID: 10},
+	})
+
+	tests := []struct {
+		name               string
+		contentID          int
+		content            *AudioContent
+		expectedSuppressed bool
+		expectedCategory   bool
+	}{
+		{name: "興味なしのコンテンツ", contentID: 1, content: nil, expectedSuppressed: true},
+		{name: "興味なしの作者", contentID: 2, content: &AudioContent{ID: 2, AuthorID: 100, CategoryID: 20}, expectedSuppressed: true},
+		{name: "興味なしのカテゴリ", contentID: 3, content: &AudioContent{ID: 3, AuthorID: 200, CategoryID: 10}, expectedCategory: true},
+		{name: "対象外", contentID: 4, content: &AudioContent{ID: 4, AuthorID: 200, CategoryID: 20}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := set.Suppresses(tt.contentID, tt.content); got != tt.expectedSuppressed {
+				t.Errorf("Suppresses: %vを期待しましたが、%vを取得しました", tt.expectedSuppressed, got)
+			}
+			if got := set.DismissedCategory(tt.content); got != tt.expectedCategory {
+				t.Errorf("DismissedCategory: %vを期待しましたが、%vを取得しました", tt.expectedCategory, got)
+			}
+		})
+	}
+
+	var empty *DismissalSet
+	if !empty.IsEmpty() || empty.Suppresses(1, nil) {
+		t.Error("nilの集合は空として扱われることを期待しました")
+	}
+}
@@ -0,0 +1,82 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// FactorModel 行列分解で学習したユーザー・アイテムの潜在因子モデル
+type FactorModel struct {
+	Version     string
+	TrainedAt   time.Time
+	Factors     int
+	UserIDs     []int
+	ItemIDs     []int
+	UserFactors [][]float64
+	ItemFactors [][]float64
+
+	userIndex map[int]int
+}
+
+// ScoredItem スコア付きアイテム
+type ScoredItem struct {
+	AudioContentID int
+	Score          float64
+}
+
+// Reindex ユーザーIDの逆引きを構築（デコード後に呼び出す）
+func (m *FactorModel) Reindex() {
+	m.userIndex = make(map[int]int, len(m.UserIDs))
+	for i, userID := range m.UserIDs {
+		m.userIndex[userID] = i
+	}
+}
+
+// HasUser ユーザーの因子を持つかチェック
+func (m *FactorModel) HasUser(userID int) bool {
+	if m == nil {
+		return false
+	}
+	_, ok := m.userIndex[userID]
+	return ok
+}
+
+// RecommendForUser ユーザー因子とアイテム因子の内積でスコアの高いアイテムを取得
+func (m *FactorModel) RecommendForUser(userID int, exclude map[int]bool, limit int) []*ScoredItem {
+	if !m.HasUser(userID) {
+		return nil
+	}
+	userVector := m.UserFactors[m.userIndex[userID]]
+
+	scored := make([]*ScoredItem, 0, len(m.ItemIDs))
+	for i, itemID := range m.ItemIDs {
+		if exclude[itemID] {
+			continue
+		}
+		scored = append(scored, &ScoredItem{
+			AudioContentID: itemID,
+			Score:          dot(userVector, m.ItemFactors[i]),
+		})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].Score != scored[j].Score {
+			return scored[i].Score > scored[j].Score
+		}
+		return scored[i].AudioContentID < scored[j].AudioContentID
+	})
+	if len(scored) > limit {
+		scored = scored[:limit]
+	}
+
+	return scored
+}
+
+// dot ベクトルの内積
+func dot(a, b []float64) float64 {
+	sum := 0.0
+	for i := range a {
+		sum += a[i] * b[i]
+	}
+	return sum
+}
@@ -0,0 +1,131 @@
+package entities
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	// 1ユーザーあたりに考慮するアイテム数（ペア数の爆発を防ぐ）
+	maxItemsPerUser = 200
+
+	// 類似度を採用する最低共起ユーザー数
+	minCoOccurrence = 2
+)
+
+// ItemNeighbor 近傍アイテムと類似度
+type ItemNeighbor struct {
+	AudioContentID int
+	Similarity     float64 // コサイン類似度（0.0-1.0）
+}
+
+// ItemSimilarityIndex アイテム間コサイン類似度のインデックス
+type ItemSimilarityIndex struct {
+	neighbors map[int][]*ItemNeighbor
+	BuiltAt   time.Time
+}
+
+// BuildItemSimilarityIndex 再生履歴からアイテム間類似度インデックスを構築
+// ユーザー×アイテム行列の値は再生からの経過時間で減衰させたエンゲージメントスコアの合計、各アイテムは上位topK件の近傍のみ保持する
+func BuildItemSimilarityIndex(playbacks []*PlaybackHistory, topK int, decay DecayConfig) *ItemSimilarityIndex {
+	builtAt := time.Now()
+
+	// ユーザーごとのアイテム重み
+	userItems := make(map[int]map[int]float64)
+	for _, playback := range playbacks {
+		items, ok := userItems[playback.UserID]
+		if !ok {
+			items = make(map[int]float64)
+			userItems[playback.UserID] = items
+		}
+		items[playback.AudioContentID] += playback.CalculateEngagementScore(decay, builtAt)
+	}
+
+	norms := make(map[int]float64)
+	dots := make(map[[2]int]float64)
+	counts := make(map[[2]int]int)
+
+	for _, items := range userItems {
+		ids := make([]int, 0, len(items))
+		for id, weight := range items {
+			if weight <= 0 {
+				continue
+			}
+			ids = append(ids, id)
+		}
+		// 重みの大きいアイテムを優先して上限まで
+		sort.Slice(ids, func(i, j int) bool {
+			if items[ids[i]] != items[ids[j]] {
+				return items[ids[i]] > items[ids[j]]
+			}
+			return ids[i] < ids[j]
+		})
+		if len(ids) > maxItemsPerUser {
+			ids = ids[:maxItemsPerUser]
+		}
+
+		for _, id := range ids {
+			norms[id] += items[id] * items[id]
+		}
+		for a := 0; a < len(ids); a++ {
+			for b := a + 1; b < len(ids); b++ {
+				key := pairKey(ids[a], ids[b])
+				dots[key] += items[ids[a]] * items[ids[b]]
+				counts[key]++
+			}
+		}
+	}
+
+	neighbors := make(map[int][]*ItemNeighbor)
+	for key, dot := range dots {
+		if counts[key] < minCoOccurrence {
+			continue
+		}
+		similarity := dot / (math.Sqrt(norms[key[0]]) * math.Sqrt(norms[key[1]]))
+		neighbors[key[0]] = append(neighbors[key[0]], &ItemNeighbor{AudioContentID: key[1], Similarity: similarity})
+		neighbors[key[1]] = append(neighbors[key[1]], &ItemNeighbor{AudioContentID: key[0], Similarity: similarity})
+	}
+
+	for id, list := range neighbors {
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Similarity != list[j].Similarity {
+				return list[i].Similarity > list[j].Similarity
+			}
+			return list[i].AudioContentID < list[j].AudioContentID
+		})
+		if len(list) > topK {
+			list = list[:topK]
+		}
+		neighbors[id] = list
+	}
+
+	return &ItemSimilarityIndex{
+		neighbors: neighbors,
+		BuiltAt:   builtAt,
+	}
+}
+
+// Neighbors 指定アイテムの近傍を類似度の降順で取得
+func (idx *ItemSimilarityIndex) Neighbors(contentID int) []*ItemNeighbor {
+	if idx == nil {
+		return nil
+	}
+	return idx.neighbors[contentID]
+}
+
+// Size 近傍を持つアイテム数
+func (idx *ItemSimilarityIndex) Size() int {
+	if idx == nil {
+		return 0
+	}
+	return len(idx.neighbors)
+}
+
+// pairKey 順序を正規化したアイテムペア
+func pairKey(a, b int) [2]int {
+	if a > b {
+		a, b = b, a
+	}
+	return [2]int{a, b}
+}
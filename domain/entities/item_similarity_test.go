@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildItemSimilarityIndex(t *testing.T) {
+	playedAt := time.Now().Add(-30 * 24 * time.Hour)
+	play := func(userID, contentID int) *PlaybackHistory {
+		return &PlaybackHistory{UserID: userID, AudioContentID: contentID, PlayedAt: playedAt, Completed: true}
+	}
+
+	playbacks := []*PlaybackHistory{
+		// 1と2は3人に一緒に聴かれている
+		play(1, 1), play(1, 2),
+		play(2, 1), play(2, 2),
+		play(3, 1), play(3, 2), play(3, 3),
+		// 1と3の共起は1人だけ
+		play(4, 4),
+	}
+
+	index := BuildItemSimilarityIndex(playbacks, 10, DecayConfig{})
+
+	neighbors := index.Neighbors(1)
+	if len(neighbors) != 1 {
+		t.Fatalf("1件の近傍を期待しましたが、%d件を取得しました", len(neighbors))
+	}
+	if neighbors[0].AudioContentID != 2 {
+		t.Errorf("近傍としてコンテンツ2を期待しましたが、%d を取得しました", neighbors[0].AudioContentID)
+	}
+	if neighbors[0].Similarity < 0.99 || neighbors[0].Similarity > 1.0001 {
+		t.Errorf("類似度 1.0 付近を期待しましたが、%f を取得しました", neighbors[0].Similarity)
+	}
+
+	// 共起が閾値未満のペアは採用されない
+	if len(index.Neighbors(3)) != 0 {
+		t.Errorf("コンテンツ3に近傍がないことを期待しましたが、%d件を取得しました", len(index.Neighbors(3)))
+	}
+
+	if index.Size() != 2 {
+		t.Errorf("2件のアイテムを期待しましたが、%d件を取得しました", index.Size())
+	}
+}
+
+func TestBuildItemSimilarityIndex_TopK(t *testing.T) {
+	var playbacks []*PlaybackHistory
+	for userID := 1; userID <= 3; userID++ {
+		for contentID := 1; contentID <= 5; contentID++ {
+			playbacks = append(playbacks, &PlaybackHistory{
+				UserID:         userID,
+				AudioContentID: contentID,
+				PlayedAt:       time.Now(),
+				Duration:       120,
+			})
+		}
+	}
+
+	index := BuildItemSimilarityIndex(playbacks, 2, DecayConfig{})
+
+	if got := len(index.Neighbors(1)); got != 2 {
+		t.Errorf("近傍はtopK=2件を期待しましたが、%d件を取得しました", got)
+	}
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"fmt"
+	"time"
+)
+
+// DayPart 時間帯
+type DayPart string
+
+const (
+	DayPartMorning DayPart = "morning" // 5時〜10時（通勤・朝）
+	DayPartDaytime DayPart = "daytime" // 10時〜17時
+	DayPartEvening DayPart = "evening" // 17時〜22時
+	DayPartNight   DayPart = "night"   // 22時〜5時
+)
+
+// TimeSlot 曜日の種類（平日・週末）と時間帯の組
+type TimeSlot struct {
+	DayPart DayPart
+	Weekend bool
+}
+
+// AllTimeSlots すべての時間枠
+func AllTimeSlots() []TimeSlot {
+	var slots []TimeSlot
+	for _, weekend := range []bool{false, true} {
+		for _, part := range []DayPart{DayPartMorning, DayPartDaytime, DayPartEvening, DayPartNight} {
+			slots = append(slots, TimeSlot{DayPart: part, Weekend: weekend})
+		}
+	}
+	return slots
+}
+
+// TimeSlotAt 現地時刻の時間枠（深夜0時〜5時は前日の夜として扱う）
+func TimeSlotAt(t time.Time) TimeSlot {
+	hour := t.Hour()
+	day := t
+	var part DayPart
+	switch {
+	case hour < 5:
+		part = DayPartNight
+		day = t.AddDate(0, 0, -1)
+	case hour < 10:
+		part = DayPartMorning
+	case hour < 17:
+		part = DayPartDaytime
+	case hour < 22:
+		part = DayPartEvening
+	default:
+		part = DayPartNight
+	}
+
+	weekday := day.Weekday()
+	return TimeSlot{
+		DayPart: part,
+		Weekend: weekday == time.Saturday || weekday == time.Sunday,
+	}
+}
+
+// String 時間枠の識別子（例: weekday_morning）
+func (s TimeSlot) String() string {
+	if s.Weekend {
+		return fmt.Sprintf("weekend_%s", s.DayPart)
+	}
+	return fmt.Sprintf("weekday_%s", s.DayPart)
+}
+
+// DeviceType リクエスト元の端末種別
+type DeviceType string
+
+const (
+	DeviceUnknown      DeviceType = "unknown"
+	DeviceMobile       DeviceType = "mobile"
+	DeviceDesktop      DeviceType = "desktop"
+	DeviceSmartSpeaker DeviceType = "smart_speaker"
+	DeviceCar          DeviceType = "car"
+)
+
+// IsValid 既知の端末種別かどうか判定
+func (d DeviceType) IsValid() bool {
+	switch d {
+	case DeviceUnknown, DeviceMobile, DeviceDesktop, DeviceSmartSpeaker, DeviceCar:
+		return true
+	}
+	return false
+}
+
+// ListeningContext レコメンドを要求した時点の利用状況
+type ListeningContext struct {
+	LocalTime time.Time // 利用者のタイムゾーンでの現在時刻
+	Device    DeviceType
+}
+
+// Slot 現在の時間枠
+func (c *ListeningContext) Slot() TimeSlot {
+	return TimeSlotAt(c.LocalTime)
+}
+
+// Location 利用者のタイムゾーン
+func (c *ListeningContext) Location() *time.Location {
+	return c.LocalTime.Location()
+}
@@ -0,0 +1,191 @@
+package entities
+
+import (
+	"math"
+	"sort"
+)
+
+// QueueConfig 再生キューの組み立ての設定
+type QueueConfig struct {
+	ToleranceRatio float64 // 目標時間に対して許容する過不足の割合
+	Granularity    int     // 組み合わせを探すときに長さを丸める単位（秒）
+	MaxPerCategory int     // 同じカテゴリから入れる最大件数
+	MaxPerAuthor   int     // 同じ作者から入れる最大件数
+}
+
+// DefaultQueueConfig デフォルト設定
+func DefaultQueueConfig() QueueConfig {
+	return QueueConfig{
+		ToleranceRatio: 0.1,
+		Granularity:    30,
+		MaxPerCategory: 2,
+		MaxPerAuthor:   1,
+	}
+}
+
+// QueueCandidate キューの候補（レコメンドのスコア付きコンテンツ）
+type QueueCandidate struct {
+	Content *AudioContent
+	Score   float64
+	Reason  RecommendationReason
+}
+
+// ListeningQueue 目標時間に合わせて組み立てた再生キュー（Items は再生順）
+type ListeningQueue struct {
+	Items          []*QueueCandidate
+	TargetDuration int // 秒
+	TotalDuration  int // 秒
+}
+
+// BuildListeningQueue 長さの合計が目標時間 ± 許容幅に収まる組み合わせのうち、スコアの合計が最大のものを選んで再生順に並べる
+// 多様性のため、スコア順にカテゴリ・作者ごとの上限を超える候補を除いてから、長さを Granularity 秒単位に丸めたナップサック問題として解く
+// 丸めの誤差は件数が多いほど積み重なるため、実際の合計が許容幅を外れたら単位を半分にして解き直す（1秒単位では誤差がない）
+// 許容幅に収まる組み合わせがなければ、目標時間 + 許容幅を超えない範囲で最も長くなる組み合わせを返す
+func BuildListeningQueue(candidates []*QueueCandidate, targetDuration int, config QueueConfig) *ListeningQueue {
+	queue := &ListeningQueue{TargetDuration: targetDuration}
+	tolerance := int(float64(targetDuration) * config.ToleranceRatio)
+	minDuration, maxDuration := targetDuration-tolerance, targetDuration+tolerance
+
+	pool := diverseQueuePool(candidates, maxDuration, config)
+	if len(pool) == 0 {
+		return queue
+	}
+
+	granularity := config.Granularity
+	if granularity <= 0 {
+		granularity = 1
+	}
+	var selected []*QueueCandidate
+	total := 0
+	for {
+		selected = solveQueueKnapsack(pool, minDuration, maxDuration, granularity)
+		total = 0
+		for _, c := range selected {
+			total += c.Content.Duration
+		}
+		if granularity == 1 || (total >= minDuration && total <= maxDuration) {
+			break
+		}
+		granularity /= 2
+	}
+
+	queue.Items = listeningOrder(selected)
+	queue.TotalDuration = total
+	return queue
+}
+
+// solveQueueKnapsack 長さを granularity 秒単位に丸め、丸めた合計が minDuration〜maxDuration に収まる組み合わせのうちスコアの合計が最大のものを選ぶ
+// 収まる組み合わせがなければ、丸めた合計が maxDuration を超えない範囲で最も長いもの
+func solveQueueKnapsack(pool []*QueueCandidate, minDuration, maxDuration, granularity int) []*QueueCandidate {
+	lower := (minDuration + granularity/2) / granularity
+	capacity := maxDuration / granularity
+	if capacity <= 0 {
+		return nil
+	}
+
+	units := make([]int, len(pool))
+	for i, c := range pool {
+		units[i] = int(math.Max(1, math.Round(float64(c.Content.Duration)/float64(granularity))))
+	}
+
+	// best[u]: 丸めた長さの合計がちょうど u になる組み合わせの最大スコア（到達不能は -Inf）
+	best := make([]float64, capacity+1)
+	for u := 1; u <= capacity; u++ {
+		best[u] = math.Inf(-1)
+	}
+	taken := make([][]bool, len(pool))
+	for i, c := range pool {
+		taken[i] = make([]bool, capacity+1)
+		for u := capacity; u >= units[i]; u-- {
+			if math.IsInf(best[u-units[i]], -1) {
+				continue
+			}
+			if score := best[u-units[i]] + c.Score; score > best[u] {
+				best[u] = score
+				taken[i][u] = true
+			}
+		}
+	}
+
+	chosen := -1
+	for u := lower; u <= capacity; u++ {
+		if u > 0 && !math.IsInf(best[u], -1) && (chosen < 0 || best[u] > best[chosen]) {
+			chosen = u
+		}
+	}
+	if chosen < 0 {
+		for u := capacity; u > 0; u-- {
+			if !math.IsInf(best[u], -1) {
+				chosen = u
+				break
+			}
+		}
+	}
+	if chosen < 0 {
+		return nil
+	}
+
+	var selected []*QueueCandidate
+	for i, u := len(pool)-1, chosen; i >= 0 && u > 0; i-- {
+		if taken[i][u] {
+			selected = append(selected, pool[i])
+			u -= units[i]
+		}
+	}
+	return selected
+}
+
+// diverseQueuePool 長さが不明・長すぎる候補を除き、スコアの高い順にカテゴリ・作者ごとの上限までに絞る
+func diverseQueuePool(candidates []*QueueCandidate, maxDuration int, config QueueConfig) []*QueueCandidate {
+	sorted := make([]*QueueCandidate, 0, len(candidates))
+	for _, c := range candidates {
+		if c.Content != nil && c.Content.Duration > 0 && c.Content.Duration <= maxDuration && c.Score > 0 {
+			sorted = append(sorted, c)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Score > sorted[j].Score
+	})
+
+	categoryCounts := make(map[int]int)
+	authorCounts := make(map[int]int)
+	var pool []*QueueCandidate
+	for _, c := range sorted {
+		if config.MaxPerCategory > 0 && categoryCounts[c.Content.CategoryID] >= config.MaxPerCategory {
+			continue
+		}
+		if config.MaxPerAuthor > 0 && authorCounts[c.Content.AuthorID] >= config.MaxPerAuthor {
+			continue
+		}
+		categoryCounts[c.Content.CategoryID]++
+		authorCounts[c.Content.AuthorID]++
+		pool = append(pool, c)
+	}
+	return pool
+}
+
+// listeningOrder スコアの高い順を基本に、同じカテゴリが続かないように並べる（避けられない場合は続けて入れる）
+func listeningOrder(selected []*QueueCandidate) []*QueueCandidate {
+	remaining := make([]*QueueCandidate, len(selected))
+	copy(remaining, selected)
+	sort.SliceStable(remaining, func(i, j int) bool {
+		return remaining[i].Score > remaining[j].Score
+	})
+
+	ordered := make([]*QueueCandidate, 0, len(remaining))
+	for len(remaining) > 0 {
+		next := 0
+		if len(ordered) > 0 {
+			previous := ordered[len(ordered)-1].Content.CategoryID
+			for i, c := range remaining {
+				if c.Content.CategoryID != previous {
+					next = i
+					break
+				}
+			}
+		}
+		ordered = append(ordered, remaining[next])
+		remaining = append(remaining[:next], remaining[next+1:]...)
+	}
+	return ordered
+}
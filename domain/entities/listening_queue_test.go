@@ -0,0 +1,96 @@
+package entities
+
+import "testing"
+
+func TestBuildListeningQueue(t *testing.T) {
+	candidate := func(id, categoryID, authorID, minutes int, score float64) *QueueCandidate {
+		return &QueueCandidate{
+			Content: &AudioContent{ID: id, CategoryID: categoryID, AuthorID: authorID, Duration: minutes * 60},
+			Score:   score,
+		}
+	}
+
+	candidates := []*QueueCandidate{
+		candidate(1, 10, 100, 25, 1.0), // 最高スコアだが25分で、組み合わせると合計スコアが伸びない
+		candidate(2, 20, 200, 10, 0.8),
+		candidate(3, 30, 300, 12, 0.7),
+		candidate(4, 40, 400, 8, 0.6),
+		candidate(5, 10, 500, 20, 0.5),
+		candidate(6, 50, 200, 9, 0.75), // 作者200の2件目は多様性のため除外
+		candidate(7, 60, 700, 0, 2.0),  // 長さ不明は除外
+	}
+
+	queue := BuildListeningQueue(candidates, 30*60, DefaultQueueConfig())
+
+	// 30分 ± 3分に収まる組み合わせのうち、スコア合計が最大の 2 + 3 + 4（30分、2.1）を選ぶ
+	// （スコア順に詰めると 1 + 4（33分、1.6）になる）
+	expectedIDs := []int{2, 3, 4}
+	if len(queue.Items) != len(expectedIDs) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expectedIDs), len(queue.Items))
+	}
+	for i, item := range queue.Items {
+		if item.Content.ID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], item.Content.ID)
+		}
+	}
+	if queue.TotalDuration != 30*60 {
+		t.Errorf("合計1800秒を期待しましたが、%d秒を取得しました", queue.TotalDuration)
+	}
+}
+
+func TestListeningOrder(t *testing.T) {
+	selected := []*QueueCandidate{
+		{Content: &AudioContent{ID: 1, CategoryID: 10}, Score: 0.9},
+		{Content: &AudioContent{ID: 2, CategoryID: 10}, Score: 0.8},
+		{Content: &AudioContent{ID: 3, CategoryID: 20}, Score: 0.7},
+	}
+
+	// スコア順だと 1, 2 と同じカテゴリが続くため 3 を間に入れる
+	ordered := listeningOrder(selected)
+	expectedIDs := []int{1, 3, 2}
+	for i, item := range ordered {
+		if item.Content.ID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], item.Content.ID)
+		}
+	}
+}
+
+func TestBuildListeningQueue_NoFit(t *testing.T) {
+	candidates := []*QueueCandidate{
+		{Content: &AudioContent{ID: 1, CategoryID: 10, AuthorID: 100, Duration: 10 * 60}, Score: 1.0},
+		{Content: &AudioContent{ID: 2, CategoryID: 20, AuthorID: 200, Duration: 60 * 60}, Score: 1.0},
+	}
+
+	// 30分に届く組み合わせがなければ、超えない範囲で最も長いもの
+	queue := BuildListeningQueue(candidates, 30*60, DefaultQueueConfig())
+	if len(queue.Items) != 1 || queue.Items[0].Content.ID != 1 {
+		t.Fatalf("コンテンツ1のみを期待しましたが、%v を取得しました", queue.Items)
+	}
+	if queue.TotalDuration != 10*60 {
+		t.Errorf("合計600秒を期待しましたが、%d秒を取得しました", queue.TotalDuration)
+	}
+
+	if empty := BuildListeningQueue(nil, 30*60, DefaultQueueConfig()); len(empty.Items) != 0 {
+		t.Errorf("空のキューを期待しましたが、%d件を取得しました", len(empty.Items))
+	}
+}
+
+func TestBuildListeningQueue_ManyShortItems(t *testing.T) {
+	var candidates []*QueueCandidate
+	for i := 1; i <= 10; i++ {
+		candidates = append(candidates, &QueueCandidate{
+			Content: &AudioContent{ID: i, CategoryID: i, AuthorID: i, Duration: 44},
+			Score:   1.0,
+		})
+	}
+
+	// 44秒は30秒単位で1単位に丸まり、10件（440秒）が300秒 ± 30秒に収まって見えてしまう
+	// 実際の合計で確かめて解き直し、7件（308秒）にする
+	queue := BuildListeningQueue(candidates, 300, DefaultQueueConfig())
+	if queue.TotalDuration < 270 || queue.TotalDuration > 330 {
+		t.Fatalf("270〜330秒を期待しましたが、%d秒を取得しました", queue.TotalDuration)
+	}
+	if len(queue.Items) != 7 || queue.TotalDuration != 308 {
+		t.Errorf("7件・308秒を期待しましたが、%d件・%d秒を取得しました", len(queue.Items), queue.TotalDuration)
+	}
+}
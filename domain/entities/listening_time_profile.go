@@ -0,0 +1,94 @@
+package entities
+
+import "time"
+
+// DurationBucket コンテンツの長さの区分
+type DurationBucket string
+
+const (
+	DurationShort  DurationBucket = "short"  // 10分未満（ニュースクリップなど）
+	DurationMedium DurationBucket = "medium" // 10分〜30分
+	DurationLong   DurationBucket = "long"   // 30分以上（長尺トークなど）
+)
+
+// DurationBucketOf コンテンツの長さ（秒）の区分
+func DurationBucketOf(seconds int) DurationBucket {
+	switch {
+	case seconds < 10*60:
+		return DurationShort
+	case seconds < 30*60:
+		return DurationMedium
+	default:
+		return DurationLong
+	}
+}
+
+// ListeningTimeProfile ユーザーが時間枠ごとにどのカテゴリ・長さのコンテンツを聴いているかの集計
+type ListeningTimeProfile struct {
+	total          float64
+	categoryTotals map[int]float64
+	bucketTotals   map[DurationBucket]float64
+	slotTotals     map[TimeSlot]float64
+	slotCategories map[TimeSlot]map[int]float64
+	slotBuckets    map[TimeSlot]map[DurationBucket]float64
+}
+
+// BuildListeningTimeProfile 再生履歴の再生日時を利用者のタイムゾーンの時間枠に振り分けて集計（早期スキップは数えない）
+func BuildListeningTimeProfile(playbacks []*PlaybackHistory, contents map[int]*AudioContent, loc *time.Location) *ListeningTimeProfile {
+	profile := &ListeningTimeProfile{
+		categoryTotals: make(map[int]float64),
+		bucketTotals:   make(map[DurationBucket]float64),
+		slotTotals:     make(map[TimeSlot]float64),
+		slotCategories: make(map[TimeSlot]map[int]float64),
+		slotBuckets:    make(map[TimeSlot]map[DurationBucket]float64),
+	}
+
+	for _, playback := range playbacks {
+		content, ok := contents[playback.AudioContentID]
+		if !ok || playback.IsEarlySkip() {
+			continue
+		}
+
+		slot := TimeSlotAt(playback.PlayedAt.In(loc))
+		bucket := DurationBucketOf(content.Duration)
+
+		profile.total++
+		profile.categoryTotals[content.CategoryID]++
+		profile.bucketTotals[bucket]++
+		profile.slotTotals[slot]++
+		if profile.slotCategories[slot] == nil {
+			profile.slotCategories[slot] = make(map[int]float64)
+			profile.slotBuckets[slot] = make(map[DurationBucket]float64)
+		}
+		profile.slotCategories[slot][content.CategoryID]++
+		profile.slotBuckets[slot][bucket]++
+	}
+
+	return profile
+}
+
+// SlotPlays 時間枠での再生数
+func (p *ListeningTimeProfile) SlotPlays(slot TimeSlot) float64 {
+	return p.slotTotals[slot]
+}
+
+// CategoryLift 時間枠でのカテゴリの再生割合が全体の割合の何倍か（全体の割合を事前分布に priorWeight 回分で平滑化）
+func (p *ListeningTimeProfile) CategoryLift(slot TimeSlot, categoryID int, priorWeight float64) float64 {
+	return p.lift(p.slotCategories[slot][categoryID], p.slotTotals[slot], p.categoryTotals[categoryID], priorWeight)
+}
+
+// DurationLift 時間枠での長さの区分の再生割合が全体の割合の何倍か
+func (p *ListeningTimeProfile) DurationLift(slot TimeSlot, seconds int, priorWeight float64) float64 {
+	bucket := DurationBucketOf(seconds)
+	return p.lift(p.slotBuckets[slot][bucket], p.slotTotals[slot], p.bucketTotals[bucket], priorWeight)
+}
+
+// lift 平滑化した時間枠内の割合 / 全体の割合（全体で一度も聴いていなければ1.0）
+func (p *ListeningTimeProfile) lift(inSlot, slotTotal, overall, priorWeight float64) float64 {
+	if p.total == 0 || overall == 0 {
+		return 1.0
+	}
+	overallShare := overall / p.total
+	slotShare := (inSlot + overallShare*priorWeight) / (slotTotal + priorWeight)
+	return slotShare / overallShare
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeSlotAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		time     time.Time
+		expected string
+	}{
+		{name: "平日の朝", time: time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC), expected: "weekday_morning"},
+		{name: "平日の昼", time: time.Date(2024, 4, 1, 12, 0, 0, 0, time.UTC), expected: "weekday_daytime"},
+		{name: "土曜の夕方", time: time.Date(2024, 4, 6, 18, 0, 0, 0, time.UTC), expected: "weekend_evening"},
+		{name: "日曜深夜は週末の夜", time: time.Date(2024, 4, 7, 23, 0, 0, 0, time.UTC), expected: "weekend_night"},
+		{name: "月曜未明は前日（日曜）の夜", time: time.Date(2024, 4, 8, 2, 0, 0, 0, time.UTC), expected: "weekend_night"},
+		{name: "土曜未明は前日（金曜）の夜", time: time.Date(2024, 4, 6, 2, 0, 0, 0, time.UTC), expected: "weekday_night"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TimeSlotAt(tt.time).String(); got != tt.expected {
+				t.Errorf("%sを期待しましたが、%sを取得しました", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestListeningTimeProfile_Lift(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	contents := map[int]*AudioContent{
+		1: {ID: 1, CategoryID: 10, Duration: 5 * 60},  // ニュース（短い）
+		2: {ID: 2, CategoryID: 20, Duration: 60 * 60}, // トーク（長い）
+	}
+
+	// 平日の朝（JST）はニュース、夜はトークを聴く
+	var playbacks []*PlaybackHistory
+	for day := 1; day <= 5; day++ {
+		morning := time.Date(2024, 4, day, 8, 0, 0, 0, tokyo)
+		night := time.Date(2024, 4, day, 23, 0, 0, 0, tokyo)
+		playbacks = append(playbacks,
+			&PlaybackHistory{UserID: 1, AudioContentID: 1, PlayedAt: morning.UTC(), Completed: true},
+			&PlaybackHistory{UserID: 1, AudioContentID: 2, PlayedAt: night.UTC(), Completed: true},
+		)
+	}
+	// 早期スキップは数えない
+	playbacks = append(playbacks, &PlaybackHistory{
+		UserID: 1, AudioContentID: 2, PlayedAt: time.Date(2024, 4, 1, 8, 30, 0, 0, tokyo), Duration: 5, ContentDuration: 60 * 60,
+	})
+
+	profile := BuildListeningTimeProfile(playbacks, contents, tokyo)
+	morning := TimeSlot{DayPart: DayPartMorning}
+
+	if got := profile.SlotPlays(morning); got != 5 {
+		t.Errorf("朝の再生数5を期待しましたが、%vを取得しました", got)
+	}
+	if lift := profile.CategoryLift(morning, 10, 5); lift <= 1.0 {
+		t.Errorf("朝のニュースは1.0より大きい倍率を期待しましたが、%vを取得しました", lift)
+	}
+	if lift := profile.CategoryLift(morning, 20, 5); lift >= 1.0 {
+		t.Errorf("朝のトークは1.0より小さい倍率を期待しましたが、%vを取得しました", lift)
+	}
+	if lift := profile.DurationLift(morning, 3*60, 5); lift <= 1.0 {
+		t.Errorf("朝の短いコンテンツは1.0より大きい倍率を期待しましたが、%vを取得しました", lift)
+	}
+
+	// 聴いたことのないカテゴリ・聴いていない時間枠は補正しない
+	if lift := profile.CategoryLift(morning, 30, 5); lift != 1.0 {
+		t.Errorf("未知のカテゴリは1.0を期待しましたが、%vを取得しました", lift)
+	}
+	weekendMorning := TimeSlot{DayPart: DayPartMorning, Weekend: true}
+	if lift := profile.CategoryLift(weekendMorning, 10, 5); lift != 1.0 {
+		t.Errorf("再生のない時間枠は1.0を期待しましたが、%vを取得しました", lift)
+	}
+}
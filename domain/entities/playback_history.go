@@ -1,14 +1,18 @@
 package entities
 
-import "time"
+import (
+	"sort"
+	"time"
+)
 
 // PlaybackHistory 再生履歴のドメインエンティティ
 type PlaybackHistory struct {
-	UserID         int
-	AudioContentID int
-	PlayedAt       time.Time
-	Duration       int  // 実際の再生時間（秒）
-	Completed      bool // 最後まで聞いたか
+	UserID          int
+	AudioContentID  int
+	PlayedAt        time.Time
+	Duration        int  // 実際の再生時間（秒）
+	Completed       bool // 最後まで聞いたか
+	ContentDuration int  // コンテンツの長さ（秒、不明なら0）
 }
 
 // IsValid 再生履歴の妥当性をチェック
@@ -16,27 +20,50 @@ func (ph *PlaybackHistory) IsValid() bool {
 	return ph.UserID > 0 && ph.AudioContentID > 0 && !ph.PlayedAt.IsZero()
 }
 
-// IsRecentPlay 最近の再生かどうか判定
-func (ph *PlaybackHistory) IsRecentPlay(days int) bool {
-	return time.Since(ph.PlayedAt) <= time.Duration(days)*24*time.Hour
-}
+// エンゲージメントの閾値（再生時間 / コンテンツの長さ）
+const (
+	EarlySkipRatio   = 0.1 // これ未満で離脱したら早期スキップ
+	FullListenRatio  = 0.9 // これ以上聴いたら完了とみなす
+	EarlySkipSeconds = 30  // コンテンツの長さが不明な場合の早期スキップ（秒）
+	EarlySkipScore   = -1.0
+	FullListenScore  = 2.0
+)
 
-// CalculateEngagementScore エンゲージメントスコアを計算
-func (ph *PlaybackHistory) CalculateEngagementScore() float64 {
-	baseScore := 1.0
-	
+// IsEarlySkip 早期スキップ（否定的なシグナル）かどうか判定
+func (ph *PlaybackHistory) IsEarlySkip() bool {
 	if ph.Completed {
-		baseScore *= 2.0 // 完了した場合は2倍
-	} else if ph.Duration > 60 { // 1分以上聞いた場合
-		baseScore *= 1.5
+		return false
+	}
+	if ph.ContentDuration > 0 {
+		return float64(ph.Duration) < float64(ph.ContentDuration)*EarlySkipRatio
 	}
-	
-	// 最近の再生は高スコア
-	if ph.IsRecentPlay(7) {
-		baseScore *= 1.2
+	return ph.Duration < EarlySkipSeconds
+}
+
+// BaseEngagementScore 経過時間による減衰を掛ける前のエンゲージメントスコア
+// コンテンツの長さに対する再生割合で決まり、早期スキップは負の値になる
+func (ph *PlaybackHistory) BaseEngagementScore() float64 {
+	switch {
+	case ph.IsEarlySkip():
+		return EarlySkipScore
+	case ph.Completed:
+		return FullListenScore
+	case ph.ContentDuration > 0:
+		ratio := float64(ph.Duration) / float64(ph.ContentDuration)
+		if ratio >= FullListenRatio {
+			return FullListenScore
+		}
+		return FullListenScore * ratio
+	case ph.Duration > 60: // 長さ不明で1分以上聞いた場合
+		return 1.5
+	default:
+		return 1.0
 	}
-	
-	return baseScore
+}
+
+// CalculateEngagementScore エンゲージメントスコアを計算（再生からの経過時間で指数減衰）
+func (ph *PlaybackHistory) CalculateEngagementScore(decay DecayConfig, now time.Time) float64 {
+	return ph.BaseEngagementScore() * decay.PlayRecency(ph.PlayedAt, now)
 }
 
 // UserPreference ユーザーの好みを表現
@@ -55,4 +82,20 @@ func (up *UserPreference) IsStrong() bool {
 // IsWeak 弱い好みかどうか判定
 func (up *UserPreference) IsWeak() bool {
 	return up.Score < 0.3
+}
+
+// ApplyRecencyDecay 最終更新からの経過時間で好み度を減衰させる
+func (up *UserPreference) ApplyRecencyDecay(decay DecayConfig, now time.Time) {
+	up.Score *= decay.PreferenceRecency(up.UpdatedAt, now)
+}
+
+// DecayedPreferences 好み度を減衰させて高い順に並べる（好み度を使うスコア計算はすべてこれを通す）
+func DecayedPreferences(preferences []*UserPreference, decay DecayConfig, now time.Time) []*UserPreference {
+	for _, preference := range preferences {
+		preference.ApplyRecencyDecay(decay, now)
+	}
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].Score > preferences[j].Score
+	})
+	return preferences
 }
\ No newline at end of file
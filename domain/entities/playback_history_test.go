@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPlaybackHistory_BaseEngagementScore(t *testing.T) {
+	tests := []struct {
+		name     string
+		playback *PlaybackHistory
+		expected float64
+	}{
+		{
+			name:     "5秒でスキップ",
+			playback: &PlaybackHistory{Duration: 5, ContentDuration: 600},
+			expected: EarlySkipScore,
+		},
+		{
+			name:     "半分まで再生",
+			playback: &PlaybackHistory{Duration: 300, ContentDuration: 600},
+			expected: 1.0,
+		},
+		{
+			name:     "ほぼ最後まで再生",
+			playback: &PlaybackHistory{Duration: 570, ContentDuration: 600},
+			expected: FullListenScore,
+		},
+		{
+			name:     "完了",
+			playback: &PlaybackHistory{Duration: 10, ContentDuration: 600, Completed: true},
+			expected: FullListenScore,
+		},
+		{
+			name:     "長さ不明で短い再生",
+			playback: &PlaybackHistory{Duration: 5},
+			expected: EarlySkipScore,
+		},
+		{
+			name:     "長さ不明で1分以上",
+			playback: &PlaybackHistory{Duration: 90},
+			expected: 1.5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.playback.BaseEngagementScore(); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("%vを期待しましたが、%vを取得しました", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestPlaybackHistory_CalculateEngagementScore(t *testing.T) {
+	now := time.Now()
+	decay := DecayConfig{PlayRecencyHalfLife: 30 * 24 * time.Hour}
+
+	tests := []struct {
+		name     string
+		playback *PlaybackHistory
+		expected float64
+	}{
+		{
+			name:     "直後の完了は減衰しない",
+			playback: &PlaybackHistory{PlayedAt: now, Duration: 600, ContentDuration: 600},
+			expected: FullListenScore,
+		},
+		{
+			name:     "半減期前の完了は半分",
+			playback: &PlaybackHistory{PlayedAt: now.Add(-30 * 24 * time.Hour), Duration: 600, ContentDuration: 600},
+			expected: FullListenScore / 2,
+		},
+		{
+			name:     "古いスキップほど否定が弱い",
+			playback: &PlaybackHistory{PlayedAt: now.Add(-60 * 24 * time.Hour), Duration: 5, ContentDuration: 600},
+			expected: EarlySkipScore / 4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.playback.CalculateEngagementScore(decay, now); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("%vを期待しましたが、%vを取得しました", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDecayedPreferences(t *testing.T) {
+	now := time.Now()
+	decay := DefaultDecayConfig()
+	preferences := []*UserPreference{
+		{UserID: 1, CategoryID: 10, Score: 0.9, UpdatedAt: now.Add(-2 * decay.PreferenceRecencyHalfLife)}, // 0.225 に減衰
+		{UserID: 1, CategoryID: 20, Score: 0.5, UpdatedAt: now},
+	}
+
+	decayed := DecayedPreferences(preferences, decay, now)
+
+	// しばらく聴いていないカテゴリは、最近聴いた好み度の低いカテゴリより下になる
+	if decayed[0].CategoryID != 20 || decayed[1].CategoryID != 10 {
+		t.Errorf("カテゴリ 20, 10 の順を期待しましたが、%d, %d を取得しました", decayed[0].CategoryID, decayed[1].CategoryID)
+	}
+	if math.Abs(decayed[1].Score-0.225) > 1e-9 {
+		t.Errorf("減衰後のスコア 0.225 を期待しましたが、%v を取得しました", decayed[1].Score)
+	}
+}
@@ -6,10 +6,26 @@ import "time"
 type RecommendationReason string
 
 const (
-	ReasonSimilarUsers   RecommendationReason = "similar_users"
-	ReasonContentBased   RecommendationReason = "content_based"
-	ReasonPopular        RecommendationReason = "popular"
-	ReasonNewContent     RecommendationReason = "new_content"
+	ReasonSimilarUsers       RecommendationReason = "similar_users"
+	ReasonContentBased       RecommendationReason = "content_based"
+	ReasonPopular            RecommendationReason = "popular"
+	ReasonNewContent         RecommendationReason = "new_content"
+	ReasonCoListening        RecommendationReason = "co_listening"
+	ReasonSimilarItems       RecommendationReason = "similar_items"
+	ReasonLatentFactors      RecommendationReason = "latent_factors"
+	ReasonPreferredCategory  RecommendationReason = "preferred_category"
+	ReasonContinueListening  RecommendationReason = "continue_listening"
+	ReasonTrending           RecommendationReason = "trending"
+	ReasonNextInSession      RecommendationReason = "next_in_session"
+	ReasonBecauseYouListened RecommendationReason = "because_you_listened"
+)
+
+// MergeStrategy 同一コンテンツのスコア統合方法
+type MergeStrategy string
+
+const (
+	MergeSum MergeStrategy = "sum" // 各ソースのスコアを合算
+	MergeMax MergeStrategy = "max" // 最大のスコアを採用
 )
 
 // Recommendation レコメンドエンティティ
@@ -17,8 +33,26 @@ type Recommendation struct {
 	UserID         int
 	AudioContentID int
 	Score          float64
-	Reason         RecommendationReason
+	Reason         RecommendationReason   // 最もスコアに寄与した理由
+	Reasons        []RecommendationReason // 寄与したすべての理由
 	GeneratedAt    time.Time
+
+	// 説明用の情報（キャッシュ・v1レスポンスには含めない）
+	SeedContentIDs []int                       `json:"-"` // ソースが根拠にした再生コンテンツ
+	SimilarUserIDs []int                       `json:"-"` // ソースが根拠にした類似ユーザー
+	Contributions  []*SourceContribution       `json:"-"` // ソースごとのスコア寄与
+	Adjustments    []*RecommendationAdjustment `json:"-"` // 適用されたフィルタ・ブースト
+}
+
+// AllReasons 寄与したすべての理由を取得
+func (r *Recommendation) AllReasons() []RecommendationReason {
+	if len(r.Reasons) > 0 {
+		return r.Reasons
+	}
+	if r.Reason != "" {
+		return []RecommendationReason{r.Reason}
+	}
+	return nil
 }
 
 // IsValid レコメンドの妥当性をチェック
@@ -45,6 +79,71 @@ func (rs *RecommendationSet) AddRecommendation(rec *Recommendation) {
 	}
 }
 
+// MergeDuplicates 同一コンテンツの候補を1件に統合（初出順を維持し、理由はすべて保持）
+func (rs *RecommendationSet) MergeDuplicates(strategy MergeStrategy) {
+	merged := make([]*Recommendation, 0, len(rs.Recommendations))
+	byContent := make(map[int]*Recommendation, len(rs.Recommendations))
+	bestScore := make(map[int]float64, len(rs.Recommendations))
+	merges := make(map[int]int)
+
+	for _, rec := range rs.Recommendations {
+		existing, ok := byContent[rec.AudioContentID]
+		if !ok {
+			copied := *rec
+			copied.Reasons = append([]RecommendationReason(nil), rec.AllReasons()...)
+			copied.Contributions = append([]*SourceContribution(nil), rec.Contributions...)
+			byContent[rec.AudioContentID] = &copied
+			bestScore[rec.AudioContentID] = rec.Score
+			merged = append(merged, &copied)
+			continue
+		}
+
+		switch strategy {
+		case MergeMax:
+			if rec.Score > existing.Score {
+				existing.Score = rec.Score
+			}
+		default:
+			existing.Score += rec.Score
+		}
+
+		// 最も寄与の大きい理由を主理由にする
+		if rec.Score > bestScore[rec.AudioContentID] {
+			bestScore[rec.AudioContentID] = rec.Score
+			existing.Reason = rec.Reason
+		}
+
+		for _, reason := range rec.AllReasons() {
+			if !containsReason(existing.Reasons, reason) {
+				existing.Reasons = append(existing.Reasons, reason)
+			}
+		}
+
+		existing.SeedContentIDs = AppendUniqueIDs(existing.SeedContentIDs, rec.SeedContentIDs...)
+		existing.SimilarUserIDs = AppendUniqueIDs(existing.SimilarUserIDs, rec.SimilarUserIDs...)
+		existing.Contributions = append(existing.Contributions, rec.Contributions...)
+		merges[rec.AudioContentID]++
+	}
+
+	for _, rec := range merged {
+		if count := merges[rec.AudioContentID]; count > 0 {
+			rec.AddAdjustment(AdjustmentMerged, "%d件の候補を%sで統合", count+1, strategy)
+		}
+	}
+
+	rs.Recommendations = merged
+}
+
+// containsReason 理由が含まれるかチェック
+func containsReason(reasons []RecommendationReason, reason RecommendationReason) bool {
+	for _, r := range reasons {
+		if r == reason {
+			return true
+		}
+	}
+	return false
+}
+
 // SortByScore スコア順にソート
 func (rs *RecommendationSet) SortByScore() {
 	for i := 0; i < len(rs.Recommendations); i++ {
@@ -0,0 +1,57 @@
+package entities
+
+import "fmt"
+
+// AdjustmentType 候補に適用されたフィルタ・ブーストの種別
+type AdjustmentType string
+
+const (
+	AdjustmentMerged            AdjustmentType = "merged"             // 複数ソースの候補を統合
+	AdjustmentDiversityRerank   AdjustmentType = "diversity_rerank"   // 多様性リランキングで順位が変動
+	AdjustmentPinned            AdjustmentType = "pinned"             // 固定枠に挿入
+	AdjustmentDismissedCategory AdjustmentType = "dismissed_category" // 興味なしのカテゴリのため減点
+	AdjustmentTimeContext       AdjustmentType = "time_context"       // 時間帯の傾向・端末に合わせて加減点
+)
+
+// SourceContribution 1ソース分のスコア寄与
+type SourceContribution struct {
+	Source          string
+	Reason          RecommendationReason
+	RawScore        float64 // ソースが出した生スコア
+	NormalizedScore float64 // 正規化後のスコア
+	Weight          float64 // ソースの重み
+	Score           float64 // 最終スコアへの寄与（NormalizedScore × Weight）
+	SeedContentIDs  []int   // 根拠になったユーザーの再生コンテンツ
+	SimilarUserIDs  []int   // 根拠になった類似ユーザー
+}
+
+// RecommendationAdjustment 適用されたフィルタ・ブースト
+type RecommendationAdjustment struct {
+	Type   AdjustmentType
+	Detail string
+}
+
+// AddAdjustment 適用されたフィルタ・ブーストを記録
+func (r *Recommendation) AddAdjustment(adjustmentType AdjustmentType, format string, args ...interface{}) {
+	r.Adjustments = append(r.Adjustments, &RecommendationAdjustment{
+		Type:   adjustmentType,
+		Detail: fmt.Sprintf(format, args...),
+	})
+}
+
+// AppendUniqueIDs 重複を除いてIDを追加
+func AppendUniqueIDs(dst []int, ids ...int) []int {
+	for _, id := range ids {
+		found := false
+		for _, existing := range dst {
+			if existing == id {
+				found = true
+				break
+			}
+		}
+		if !found {
+			dst = append(dst, id)
+		}
+	}
+	return dst
+}
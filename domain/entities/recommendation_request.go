@@ -0,0 +1,42 @@
+package entities
+
+import "sync"
+
+// RecommendationRequest レコメンド生成リクエスト（各ソースに渡される）
+type RecommendationRequest struct {
+	UserID  int
+	Limit   int
+	Context *ListeningContext // 利用状況（指定がなければnil）
+	Memo    *RequestMemo      // リクエスト内で共有する取得結果（重みの調整とソースで同じ問い合わせを繰り返さない）
+}
+
+// RequestMemo 1リクエストの間だけ取得結果を共有するメモ（並行して実行されるソースから参照される）
+type RequestMemo struct {
+	mu     sync.Mutex
+	values map[string]interface{}
+}
+
+// NewRequestMemo コンストラクタ
+func NewRequestMemo() *RequestMemo {
+	return &RequestMemo{values: make(map[string]interface{})}
+}
+
+// Load キーの取得結果を返し、未取得ならfetchで取得して保存する（失敗した結果は保存しない）
+// メモがnilの場合は毎回fetchを呼ぶ
+func (m *RequestMemo) Load(key string, fetch func() (interface{}, error)) (interface{}, error) {
+	if m == nil {
+		return fetch()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if value, ok := m.values[key]; ok {
+		return value, nil
+	}
+	value, err := fetch()
+	if err != nil {
+		return nil, err
+	}
+	m.values[key] = value
+	return value, nil
+}
@@ -189,4 +189,59 @@ func TestRecommendationSet_Limit(t *testing.T) {
 	if len(limited) != 4 {
 		t.Errorf("4件のレコメンドを期待しましたが、%d件を取得しました", len(limited))
 	}
-}
\ No newline at end of file
+}
+
+func TestRecommendationSet_MergeDuplicates(t *testing.T) {
+	newSet := func() *RecommendationSet {
+		return &RecommendationSet{
+			UserID: 1,
+			Recommendations: []*Recommendation{
+				{UserID: 1, AudioContentID: 100, Score: 1.0, Reason: ReasonPopular},
+				{UserID: 1, AudioContentID: 200, Score: 2.0, Reason: ReasonNewContent},
+				{UserID: 1, AudioContentID: 100, Score: 3.0, Reason: ReasonSimilarUsers},
+				{UserID: 1, AudioContentID: 100, Score: 0.5, Reason: ReasonPopular},
+			},
+		}
+	}
+
+	tests := []struct {
+		name          string
+		strategy      MergeStrategy
+		expectedScore float64
+	}{
+		{name: "合算", strategy: MergeSum, expectedScore: 4.5},
+		{name: "最大", strategy: MergeMax, expectedScore: 3.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recSet := newSet()
+			recSet.MergeDuplicates(tt.strategy)
+
+			if len(recSet.Recommendations) != 2 {
+				t.Fatalf("2件のレコメンドを期待しましたが、%d件を取得しました", len(recSet.Recommendations))
+			}
+
+			merged := recSet.Recommendations[0]
+			if merged.AudioContentID != 100 {
+				t.Errorf("初出順でコンテンツ100が先頭であることを期待しましたが、%d を取得しました", merged.AudioContentID)
+			}
+			if merged.Score != tt.expectedScore {
+				t.Errorf("スコア %f を期待しましたが、%f を取得しました", tt.expectedScore, merged.Score)
+			}
+			if merged.Reason != ReasonSimilarUsers {
+				t.Errorf("主理由 %s を期待しましたが、%s を取得しました", ReasonSimilarUsers, merged.Reason)
+			}
+
+			expectedReasons := []RecommendationReason{ReasonPopular, ReasonSimilarUsers}
+			if len(merged.Reasons) != len(expectedReasons) {
+				t.Fatalf("理由 %v を期待しましたが、%v を取得しました", expectedReasons, merged.Reasons)
+			}
+			for i, reason := range expectedReasons {
+				if merged.Reasons[i] != reason {
+					t.Errorf("理由 %v を期待しましたが、%v を取得しました", expectedReasons, merged.Reasons)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"sort"
+	"time"
+)
+
+// 遷移確率を採用する最低遷移回数（偶然の1回を除く）
+const minTransitionCount = 2
+
+// SplitSessions 再生履歴をユーザーごとに再生日時順に並べ、idleGap 以上間隔が空いたところでセッションに区切る
+// 早期スキップは「聴いた」とみなさずセッションから除く
+func SplitSessions(playbacks []*PlaybackHistory, idleGap time.Duration) [][]*PlaybackHistory {
+	byUser := make(map[int][]*PlaybackHistory)
+	var userIDs []int
+	for _, playback := range playbacks {
+		if playback.IsEarlySkip() {
+			continue
+		}
+		if _, ok := byUser[playback.UserID]; !ok {
+			userIDs = append(userIDs, playback.UserID)
+		}
+		byUser[playback.UserID] = append(byUser[playback.UserID], playback)
+	}
+	sort.Ints(userIDs)
+
+	var sessions [][]*PlaybackHistory
+	for _, userID := range userIDs {
+		history := byUser[userID]
+		sort.SliceStable(history, func(i, j int) bool {
+			return history[i].PlayedAt.Before(history[j].PlayedAt)
+		})
+
+		var session []*PlaybackHistory
+		for _, playback := range history {
+			if len(session) > 0 && playback.PlayedAt.Sub(session[len(session)-1].PlayedAt) >= idleGap {
+				sessions = append(sessions, session)
+				session = nil
+			}
+			session = append(session, playback)
+		}
+		if len(session) > 0 {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions
+}
+
+// Transition 次に再生されたコンテンツと遷移確率
+type Transition struct {
+	AudioContentID int
+	Probability    float64 // 前のコンテンツの次に再生された割合（0.0-1.0）
+	Count          int
+}
+
+// TransitionIndex セッション内で連続して再生されたコンテンツ間の遷移確率（1次マルコフ連鎖）
+type TransitionIndex struct {
+	next    map[int][]*Transition
+	BuiltAt time.Time
+}
+
+// BuildTransitionIndex 再生履歴をセッションに区切り、連続する再生の遷移回数から遷移確率を構築
+// 同じコンテンツの連続再生は遷移に数えず、各コンテンツは確率の高い上位topK件の遷移先のみ保持する
+func BuildTransitionIndex(playbacks []*PlaybackHistory, idleGap time.Duration, topK int) *TransitionIndex {
+	counts := make(map[int]map[int]int)
+	totals := make(map[int]int)
+	for _, session := range SplitSessions(playbacks, idleGap) {
+		for i := 1; i < len(session); i++ {
+			from, to := session[i-1].AudioContentID, session[i].AudioContentID
+			if from == to {
+				continue
+			}
+			if counts[from] == nil {
+				counts[from] = make(map[int]int)
+			}
+			counts[from][to]++
+			totals[from]++
+		}
+	}
+
+	next := make(map[int][]*Transition, len(counts))
+	for from, targets := range counts {
+		var list []*Transition
+		for to, count := range targets {
+			if count < minTransitionCount {
+				continue
+			}
+			list = append(list, &Transition{
+				AudioContentID: to,
+				Probability:    float64(count) / float64(totals[from]),
+				Count:          count,
+			})
+		}
+		if len(list) == 0 {
+			continue
+		}
+		sort.Slice(list, func(i, j int) bool {
+			if list[i].Probability != list[j].Probability {
+				return list[i].Probability > list[j].Probability
+			}
+			return list[i].AudioContentID < list[j].AudioContentID
+		})
+		if len(list) > topK {
+			list = list[:topK]
+		}
+		next[from] = list
+	}
+
+	return &TransitionIndex{
+		next:    next,
+		BuiltAt: time.Now(),
+	}
+}
+
+// Next 指定コンテンツの次に再生されたコンテンツを確率の降順で取得
+func (idx *TransitionIndex) Next(contentID int) []*Transition {
+	if idx == nil {
+		return nil
+	}
+	return idx.next[contentID]
+}
+
+// Size 遷移先を持つコンテンツ数
+func (idx *TransitionIndex) Size() int {
+	if idx == nil {
+		return 0
+	}
+	return len(idx.next)
+}
+
+// PredictNext セッションの直近 contextLength 件それぞれからの遷移確率を、新しい再生ほど重く（1つ遡るごとに contextDecay 倍）足し合わせて次の候補を予測
+// セッション内のコンテンツは候補から除く
+func (idx *TransitionIndex) PredictNext(session []int, contextLength int, contextDecay float64) []*ScoredItem {
+	inSession := make(map[int]bool, len(session))
+	for _, id := range session {
+		inSession[id] = true
+	}
+
+	scores := make(map[int]float64)
+	weight := 1.0
+	for i := len(session) - 1; i >= 0 && len(session)-i <= contextLength; i-- {
+		for _, t := range idx.Next(session[i]) {
+			if !inSession[t.AudioContentID] {
+				scores[t.AudioContentID] += weight * t.Probability
+			}
+		}
+		weight *= contextDecay
+	}
+
+	items := make([]*ScoredItem, 0, len(scores))
+	for id, score := range scores {
+		items = append(items, &ScoredItem{AudioContentID: id, Score: score})
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		return items[i].AudioContentID < items[j].AudioContentID
+	})
+	return items
+}
@@ -0,0 +1,84 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSplitSessions(t *testing.T) {
+	base := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+	playbacks := []*PlaybackHistory{
+		{UserID: 1, AudioContentID: 2, PlayedAt: base.Add(10 * time.Minute), Completed: true},
+		{UserID: 1, AudioContentID: 1, PlayedAt: base, Completed: true},
+		{UserID: 1, AudioContentID: 9, PlayedAt: base.Add(15 * time.Minute), Duration: 3, ContentDuration: 600}, // 早期スキップ
+		{UserID: 1, AudioContentID: 3, PlayedAt: base.Add(2 * time.Hour), Completed: true},
+		{UserID: 2, AudioContentID: 1, PlayedAt: base, Completed: true},
+	}
+
+	sessions := SplitSessions(playbacks, 30*time.Minute)
+
+	expected := [][]int{{1, 2}, {3}, {1}}
+	if len(sessions) != len(expected) {
+		t.Fatalf("%d件のセッションを期待しましたが、%d件を取得しました", len(expected), len(sessions))
+	}
+	for i, session := range sessions {
+		if len(session) != len(expected[i]) {
+			t.Errorf("セッション%d: %d件を期待しましたが、%d件を取得しました", i, len(expected[i]), len(session))
+			continue
+		}
+		for j, playback := range session {
+			if playback.AudioContentID != expected[i][j] {
+				t.Errorf("セッション%d[%d]: コンテンツID %d を期待しましたが、%d を取得しました", i, j, expected[i][j], playback.AudioContentID)
+			}
+		}
+	}
+}
+
+func TestTransitionIndex_PredictNext(t *testing.T) {
+	base := time.Date(2024, 4, 1, 8, 0, 0, 0, time.UTC)
+
+	// 1→2 が3回、1→3 が1回（採用しない）、2→4 が2回、5→6 が2回
+	var playbacks []*PlaybackHistory
+	sequences := [][]int{{1, 2, 4}, {1, 2, 4}, {1, 2}, {1, 3}, {5, 6}, {5, 6}}
+	for userID, sequence := range sequences {
+		for i, contentID := range sequence {
+			playbacks = append(playbacks, &PlaybackHistory{
+				UserID:         userID + 1,
+				AudioContentID: contentID,
+				PlayedAt:       base.Add(time.Duration(i) * 10 * time.Minute),
+				Completed:      true,
+			})
+		}
+	}
+
+	index := BuildTransitionIndex(playbacks, 30*time.Minute, 10)
+
+	next := index.Next(1)
+	if len(next) != 1 || next[0].AudioContentID != 2 || next[0].Probability != 0.75 {
+		t.Fatalf("1→2（確率0.75）のみを期待しましたが、%v を取得しました", next)
+	}
+
+	// 直近の再生（2）からの遷移を優先し、1つ前（5）からの遷移は半分の重みで加える
+	predicted := index.PredictNext([]int{5, 2}, 3, 0.5)
+	if len(predicted) != 2 {
+		t.Fatalf("2件の候補を期待しましたが、%d件を取得しました", len(predicted))
+	}
+	if predicted[0].AudioContentID != 4 || predicted[0].Score != 1.0 {
+		t.Errorf("コンテンツ4（1.0）を期待しましたが、%d（%v）を取得しました", predicted[0].AudioContentID, predicted[0].Score)
+	}
+	if predicted[1].AudioContentID != 6 || predicted[1].Score != 0.5 {
+		t.Errorf("コンテンツ6（0.5）を期待しましたが、%d（%v）を取得しました", predicted[1].AudioContentID, predicted[1].Score)
+	}
+
+	// セッション内のコンテンツは候補にしない
+	for _, item := range index.PredictNext([]int{4, 1}, 3, 0.5) {
+		if item.AudioContentID == 4 {
+			t.Error("セッション内のコンテンツ4が候補に含まれています")
+		}
+	}
+
+	var empty *TransitionIndex
+	if got := empty.PredictNext([]int{1}, 3, 0.5); len(got) != 0 {
+		t.Errorf("未構築のインデックスは空の結果を期待しましたが、%d件を取得しました", len(got))
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+// ShelfType ホーム画面の棚の種類
+type ShelfType string
+
+const (
+	ShelfContinueListening    ShelfType = "continue_listening"     // 続きを聴く
+	ShelfBecauseYouListened   ShelfType = "because_you_listened"   // 〜を聴いたあなたに（シードごと）
+	ShelfTrendingInCategories ShelfType = "trending_in_categories" // よく聴くカテゴリの急上昇
+	ShelfNewFromAuthors       ShelfType = "new_from_authors"       // よく聴く作者の新着
+)
+
+// 棚として表示する最低件数
+const minShelfItems = 3
+
+// Shelf ホーム画面の1行分（タイトル付きのコンテンツの並び）
+type Shelf struct {
+	ID             string // 同じ種類の棚を区別する識別子
+	Type           ShelfType
+	Title          string
+	Explanation    string // この棚が選ばれた理由
+	SeedContentIDs []int  // 根拠になったコンテンツ
+	CategoryIDs    []int  // 根拠になったカテゴリ
+	AuthorIDs      []int  // 根拠になった作者
+	Items          []*Recommendation
+}
+
+// MinItems 棚として表示する最低件数（続きを聴くは1件でも表示）
+func (s *Shelf) MinItems() int {
+	if s.Type == ShelfContinueListening {
+		return 1
+	}
+	return minShelfItems
+}
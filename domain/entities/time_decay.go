@@ -0,0 +1,53 @@
+package entities
+
+import (
+	"math"
+	"time"
+)
+
+// DecayConfig シグナルの種類ごとの指数減衰の半減期（0以下なら減衰しない）
+type DecayConfig struct {
+	ContentAgeHalfLife        time.Duration // コンテンツの公開からの経過時間（鮮度）
+	PlayRecencyHalfLife       time.Duration // 再生からの経過時間
+	PreferenceRecencyHalfLife time.Duration // カテゴリを最後に聴いてからの経過時間
+}
+
+// DefaultDecayConfig デフォルト設定
+func DefaultDecayConfig() DecayConfig {
+	return DecayConfig{
+		ContentAgeHalfLife:        7 * 24 * time.Hour,
+		PlayRecencyHalfLife:       30 * 24 * time.Hour,
+		PreferenceRecencyHalfLife: 60 * 24 * time.Hour,
+	}
+}
+
+// DecayFactor 経過時間に対する指数減衰の係数（0.5^(経過時間/半減期)、未来の時刻は1.0）
+func DecayFactor(elapsed, halfLife time.Duration) float64 {
+	if halfLife <= 0 || elapsed <= 0 {
+		return 1.0
+	}
+	return math.Pow(0.5, float64(elapsed)/float64(halfLife))
+}
+
+// ContentFreshness 公開日時からのコンテンツの鮮度（公開直後が1.0）
+func (c DecayConfig) ContentFreshness(createdAt, now time.Time) float64 {
+	return decayAt(createdAt, now, c.ContentAgeHalfLife)
+}
+
+// PlayRecency 再生日時からの再生シグナルの重み（直後が1.0）
+func (c DecayConfig) PlayRecency(playedAt, now time.Time) float64 {
+	return decayAt(playedAt, now, c.PlayRecencyHalfLife)
+}
+
+// PreferenceRecency 好みの最終更新からの好みの重み（直後が1.0）
+func (c DecayConfig) PreferenceRecency(updatedAt, now time.Time) float64 {
+	return decayAt(updatedAt, now, c.PreferenceRecencyHalfLife)
+}
+
+// decayAt 時刻が不明な場合は減衰させない
+func decayAt(at, now time.Time, halfLife time.Duration) float64 {
+	if at.IsZero() {
+		return 1.0
+	}
+	return DecayFactor(now.Sub(at), halfLife)
+}
@@ -0,0 +1,54 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestDecayFactor(t *testing.T) {
+	day := 24 * time.Hour
+
+	tests := []struct {
+		name     string
+		elapsed  time.Duration
+		halfLife time.Duration
+		expected float64
+	}{
+		{name: "経過なし", elapsed: 0, halfLife: 7 * day, expected: 1.0},
+		{name: "半減期", elapsed: 7 * day, halfLife: 7 * day, expected: 0.5},
+		{name: "半減期の2倍", elapsed: 14 * day, halfLife: 7 * day, expected: 0.25},
+		{name: "未来の時刻", elapsed: -day, halfLife: 7 * day, expected: 1.0},
+		{name: "減衰なし", elapsed: 100 * day, halfLife: 0, expected: 1.0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DecayFactor(tt.elapsed, tt.halfLife); math.Abs(got-tt.expected) > 1e-9 {
+				t.Errorf("%vを期待しましたが、%vを取得しました", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestDecayConfig_PerSignal(t *testing.T) {
+	now := time.Now()
+	weekAgo := now.Add(-7 * 24 * time.Hour)
+	decay := DefaultDecayConfig()
+
+	content := &AudioContent{CreatedAt: weekAgo}
+	if got := content.Freshness(decay, now); math.Abs(got-0.5) > 1e-9 {
+		t.Errorf("公開1週間の鮮度は0.5を期待しましたが、%vを取得しました", got)
+	}
+
+	// 同じ経過時間でも半減期の長い好みは再生より減衰が緩やか
+	play := decay.PlayRecency(weekAgo, now)
+	preference := decay.PreferenceRecency(weekAgo, now)
+	if !(content.Freshness(decay, now) < play && play < preference) {
+		t.Errorf("鮮度 < 再生 < 好み の順を期待しましたが、%v, %v, %v を取得しました", content.Freshness(decay, now), play, preference)
+	}
+
+	if got := decay.PreferenceRecency(time.Time{}, now); got != 1.0 {
+		t.Errorf("日時不明なら1.0を期待しましたが、%vを取得しました", got)
+	}
+}
@@ -0,0 +1,16 @@
+package entities
+
+// PlayVelocity コンテンツの直近ウィンドウと比較ウィンドウ（その直前の期間）の再生数
+type PlayVelocity struct {
+	AudioContentID int
+	RecentPlays    int // 直近ウィンドウの再生数
+	BaselinePlays  int // 比較ウィンドウの再生数
+}
+
+// TrendingItem 急上昇コンテンツ
+type TrendingItem struct {
+	Content       *AudioContent
+	RecentPlays   int
+	BaselinePlays int
+	Score         float64 // 比較ウィンドウに対する直近の再生ペースの倍率
+}
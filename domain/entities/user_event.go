@@ -0,0 +1,85 @@
+package entities
+
+import "time"
+
+// EventType ユーザーイベントの種別
+type EventType string
+
+const (
+	EventTypePlay     EventType = "play"
+	EventTypePause    EventType = "pause"
+	EventTypeSkip     EventType = "skip"
+	EventTypeComplete EventType = "complete"
+	EventTypeLike     EventType = "like"
+	EventTypeDislike  EventType = "dislike"
+)
+
+// ListenResumeWindow 再生を始めてから、続きのイベント（一時停止・再開・スキップ・完了）を同じ再生として記録する期間
+// 再生回数を数える集計（人気度・勢い・同時視聴・セッション）が一時停止のたびに増えないよう、1回の再生は1行にまとめる
+const ListenResumeWindow = 12 * time.Hour
+
+// IsValid 既知のイベント種別かどうか判定
+func (t EventType) IsValid() bool {
+	switch t {
+	case EventTypePlay, EventTypePause, EventTypeSkip, EventTypeComplete, EventTypeLike, EventTypeDislike:
+		return true
+	}
+	return false
+}
+
+// IsPlayback 再生履歴として記録するイベントかどうか判定
+func (t EventType) IsPlayback() bool {
+	return t.IsValid() && !t.IsRating()
+}
+
+// IsRating 明示的な評価として記録するイベントかどうか判定
+func (t EventType) IsRating() bool {
+	return t == EventTypeLike || t == EventTypeDislike
+}
+
+// UserEvent クライアントから送信されるユーザーイベント
+type UserEvent struct {
+	UserID         int
+	AudioContentID int
+	EventType      EventType
+	Duration       int // 再生時間（秒）
+	OccurredAt     time.Time
+}
+
+// IsValid イベントの妥当性をチェック
+func (e *UserEvent) IsValid() bool {
+	return e.UserID > 0 && e.AudioContentID > 0 && e.EventType.IsValid() && e.Duration >= 0
+}
+
+// ToPlaybackHistory 再生履歴に変換（再生系イベント以外はnil）
+func (e *UserEvent) ToPlaybackHistory() *PlaybackHistory {
+	if !e.EventType.IsPlayback() {
+		return nil
+	}
+
+	return &PlaybackHistory{
+		UserID:         e.UserID,
+		AudioContentID: e.AudioContentID,
+		PlayedAt:       e.OccurredAt,
+		Duration:       e.Duration,
+		Completed:      e.EventType == EventTypeComplete,
+	}
+}
+
+// ToRating 明示的な評価に変換（評価イベント以外はnil）
+func (e *UserEvent) ToRating() *UserRating {
+	if !e.EventType.IsRating() {
+		return nil
+	}
+
+	rating := RatingLike
+	if e.EventType == EventTypeDislike {
+		rating = RatingDislike
+	}
+	return &UserRating{
+		UserID:         e.UserID,
+		AudioContentID: e.AudioContentID,
+		Rating:         rating,
+		CreatedAt:      e.OccurredAt,
+	}
+}
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// 明示的な評価の値
+const (
+	RatingLike    = 1
+	RatingDislike = -1
+)
+
+// 明示的な評価のエンゲージメントスコア（完了・早期スキップより強いシグナル）
+const (
+	LikeScore    = 3.0
+	DislikeScore = -3.0
+)
+
+// UserRating ユーザーによるコンテンツの明示的な評価（いいね・よくない）
+type UserRating struct {
+	UserID         int
+	AudioContentID int
+	Rating         int // RatingLike または RatingDislike
+	CreatedAt      time.Time
+}
+
+// IsValid 評価の妥当性をチェック
+func (r *UserRating) IsValid() bool {
+	return r.UserID > 0 && r.AudioContentID > 0 && (r.Rating == RatingLike || r.Rating == RatingDislike)
+}
+
+// IsPositive いいねかどうか判定
+func (r *UserRating) IsPositive() bool {
+	return r.Rating > 0
+}
+
+// BaseEngagementScore 経過時間による減衰を掛ける前のスコア
+func (r *UserRating) BaseEngagementScore() float64 {
+	if r.IsPositive() {
+		return LikeScore
+	}
+	return DislikeScore
+}
+
+// CalculateEngagementScore 再生と同じ半減期で評価からの経過時間に応じて減衰させたスコア
+func (r *UserRating) CalculateEngagementScore(decay DecayConfig, now time.Time) float64 {
+	return r.BaseEngagementScore() * decay.PlayRecency(r.CreatedAt, now)
+}
@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUserEvent_ToRating(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		eventType EventType
+		expected  int // 0 は評価にならない
+	}{
+		{eventType: EventTypeLike, expected: RatingLike},
+		{eventType: EventTypeDislike, expected: RatingDislike},
+		{eventType: EventTypePlay, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			event := &UserEvent{UserID: 1, AudioContentID: 2, EventType: tt.eventType, OccurredAt: now}
+			rating := event.ToRating()
+
+			if tt.expected == 0 {
+				if rating != nil {
+					t.Errorf("nilを期待しましたが、%vを取得しました", rating)
+				}
+				return
+			}
+			if rating == nil || rating.Rating != tt.expected || !rating.IsValid() {
+				t.Fatalf("評価 %d を期待しましたが、%v を取得しました", tt.expected, rating)
+			}
+			if event.ToPlaybackHistory() != nil {
+				t.Error("評価イベントは再生履歴に変換されないことを期待しました")
+			}
+		})
+	}
+}
+
+func TestUserRating_CalculateEngagementScore(t *testing.T) {
+	now := time.Now()
+	decay := DecayConfig{PlayRecencyHalfLife: 30 * 24 * time.Hour}
+
+	like := &UserRating{Rating: RatingLike, CreatedAt: now}
+	if got := like.CalculateEngagementScore(decay, now); got != LikeScore {
+		t.Errorf("%vを期待しましたが、%vを取得しました", LikeScore, got)
+	}
+
+	oldDislike := &UserRating{Rating: RatingDislike, CreatedAt: now.Add(-30 * 24 * time.Hour)}
+	if got := oldDislike.CalculateEngagementScore(decay, now); math.Abs(got-DislikeScore/2) > 1e-9 {
+		t.Errorf("%vを期待しましたが、%vを取得しました", DislikeScore/2, got)
+	}
+}
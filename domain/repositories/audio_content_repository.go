@@ -12,5 +12,9 @@ type AudioContentRepository interface {
 	GetSimilarContent(ctx context.Context, categoryID, authorID int, excludeIDs []int, limit int) ([]*entities.AudioContent, error)
 	GetNewContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error)
 	GetPopularContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error)
+	GetNewContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error)
+	GetPopularContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error)
+	GetNewContentByAuthors(ctx context.Context, authorIDs []int, days int, limit int) ([]*entities.AudioContent, error)
+	GetEngagementStats(ctx context.Context, days int) ([]*entities.ContentEngagementStats, error)
 	Save(ctx context.Context, content *entities.AudioContent) error
 }
\ No newline at end of file
@@ -8,7 +8,9 @@ import (
 // CacheRepository キャッシュリポジトリのインターフェース
 type CacheRepository interface {
 	Get(ctx context.Context, key string, dest interface{}) error
+	GetMulti(ctx context.Context, keys []string, dests []interface{}) ([]bool, error)
 	Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error
+	SetIfNotExists(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error)
 	Delete(ctx context.Context, key string) error
 	Exists(ctx context.Context, key string) (bool, error)
 }
\ No newline at end of file
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+)
+
+// DismissalRepository 「興味なし」フィードバックリポジトリのインターフェース
+type DismissalRepository interface {
+	Save(ctx context.Context, dismissal *entities.Dismissal) error
+	GetByUser(ctx context.Context, userID int) ([]*entities.Dismissal, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+)
+
+// FactorModelRepository 潜在因子モデルリポジトリのインターフェース
+type FactorModelRepository interface {
+	Save(ctx context.Context, model *entities.FactorModel) error
+	LoadLatest(ctx context.Context) (*entities.FactorModel, error)
+}
@@ -3,13 +3,18 @@ package repositories
 import (
 	"context"
 	"mimiru-ai/domain/entities"
+	"time"
 )
 
 // PlaybackRepository 再生履歴リポジトリのインターフェース
 type PlaybackRepository interface {
 	GetUserHistory(ctx context.Context, userID int, limit int) ([]*entities.PlaybackHistory, error)
 	SavePlayback(ctx context.Context, history *entities.PlaybackHistory) error
+	SavePlaybacks(ctx context.Context, histories []*entities.PlaybackHistory) error
 	GetRecentPlaybacks(ctx context.Context, userID int, days int) ([]*entities.PlaybackHistory, error)
+	GetPlaybacksWithinDays(ctx context.Context, days int) ([]*entities.PlaybackHistory, error)
+	GetCoListenedContent(ctx context.Context, contentID int, days int, limit int) ([]*entities.CoListenedContent, error)
+	GetPlayVelocities(ctx context.Context, recentWindow, baselineWindow time.Duration, minRecentPlays int) ([]*entities.PlayVelocity, error)
 }
 
 // RecommendationRepository レコメンドリポジトリのインターフェース
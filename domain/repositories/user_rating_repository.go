@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+)
+
+// UserRatingRepository 明示的な評価リポジトリのインターフェース
+type UserRatingRepository interface {
+	Save(ctx context.Context, rating *entities.UserRating) error
+	GetByUser(ctx context.Context, userID int, limit int) ([]*entities.UserRating, error)
+}
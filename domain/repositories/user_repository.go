@@ -10,6 +10,7 @@ type UserRepository interface {
 	GetByID(ctx context.Context, userID int) (*entities.User, error)
 	GetSimilarUsers(ctx context.Context, userID int, limit int) ([]*entities.User, error)
 	Save(ctx context.Context, user *entities.User) error
+	SavePreferredCategories(ctx context.Context, userID int, categoryIDs []int) error
 }
 
 // UserPreferenceRepository ユーザー好みリポジトリのインターフェース
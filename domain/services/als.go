@@ -0,0 +1,234 @@
+package services
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"mimiru-ai/domain/entities"
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ALSConfig 暗黙的フィードバックALSのハイパーパラメータ
+type ALSConfig struct {
+	Factors    int                  // 潜在因子の次元数
+	Iterations int                  // 交互最小二乗の反復回数
+	Lambda     float64              // L2正則化
+	Alpha      float64              // 信頼度 c = 1 + Alpha * r の係数
+	Seed       int64                // 初期値の乱数シード
+	Decay      entities.DecayConfig // 再生からの経過時間による観測値の減衰
+}
+
+// DefaultALSConfig デフォルト設定
+func DefaultALSConfig() ALSConfig {
+	return ALSConfig{
+		Factors:    32,
+		Iterations: 10,
+		Lambda:     0.1,
+		Alpha:      40.0,
+		Seed:       42,
+		Decay:      entities.DefaultDecayConfig(),
+	}
+}
+
+// ErrNoInteractions 学習データがないエラー
+var ErrNoInteractions = errors.New("学習に使える再生履歴がありません")
+
+// alsEntry 疎行列の1要素（相手側のインデックスと観測値）
+type alsEntry struct {
+	index int
+	value float64
+}
+
+// TrainImplicitALS 再生履歴から暗黙的フィードバックALS（Hu, Koren, Volinsky 2008）で潜在因子を学習
+// 観測値 r は経過時間で減衰させたエンゲージメントスコアの合計で、r > 0 を選好あり・信頼度 1 + Alpha*r として扱う
+func TrainImplicitALS(playbacks []*entities.PlaybackHistory, config ALSConfig) (*entities.FactorModel, error) {
+	// ユーザー×アイテムの観測値を集計
+	trainedAt := time.Now().UTC()
+	interactions := make(map[[2]int]float64)
+	for _, playback := range playbacks {
+		interactions[[2]int{playback.UserID, playback.AudioContentID}] += playback.CalculateEngagementScore(config.Decay, trainedAt)
+	}
+
+	userIndex := make(map[int]int)
+	itemIndex := make(map[int]int)
+	var userIDs, itemIDs []int
+	for key, value := range interactions {
+		if value <= 0 {
+			continue
+		}
+		if _, ok := userIndex[key[0]]; !ok {
+			userIndex[key[0]] = -1
+			userIDs = append(userIDs, key[0])
+		}
+		if _, ok := itemIndex[key[1]]; !ok {
+			itemIndex[key[1]] = -1
+			itemIDs = append(itemIDs, key[1])
+		}
+	}
+	if len(userIDs) == 0 {
+		return nil, ErrNoInteractions
+	}
+
+	// 再現性のためIDを昇順に並べてインデックスを振る
+	sort.Ints(userIDs)
+	sort.Ints(itemIDs)
+	for i, id := range userIDs {
+		userIndex[id] = i
+	}
+	for i, id := range itemIDs {
+		itemIndex[id] = i
+	}
+
+	byUser := make([][]alsEntry, len(userIDs))
+	byItem := make([][]alsEntry, len(itemIDs))
+	for key, value := range interactions {
+		if value <= 0 {
+			continue
+		}
+		u, i := userIndex[key[0]], itemIndex[key[1]]
+		byUser[u] = append(byUser[u], alsEntry{index: i, value: value})
+		byItem[i] = append(byItem[i], alsEntry{index: u, value: value})
+	}
+
+	rng := rand.New(rand.NewSource(config.Seed))
+	userFactors := randomFactors(rng, len(userIDs), config.Factors)
+	itemFactors := randomFactors(rng, len(itemIDs), config.Factors)
+
+	for iter := 0; iter < config.Iterations; iter++ {
+		alsSolveSide(userFactors, itemFactors, byUser, config)
+		alsSolveSide(itemFactors, userFactors, byItem, config)
+	}
+
+	model := &entities.FactorModel{
+		Version:     trainedAt.Format("20060102T150405Z"),
+		TrainedAt:   trainedAt,
+		Factors:     config.Factors,
+		UserIDs:     userIDs,
+		ItemIDs:     itemIDs,
+		UserFactors: userFactors,
+		ItemFactors: itemFactors,
+	}
+	model.Reindex()
+
+	return model, nil
+}
+
+// alsSolveSide 片側の因子を固定して、もう片側の全行を解く
+func alsSolveSide(target, fixed [][]float64, entries [][]alsEntry, config ALSConfig) {
+	k := config.Factors
+	gram := gramMatrix(fixed, k)
+
+	workers := runtime.NumCPU()
+	rows := make(chan int, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			a := make([][]float64, k)
+			for i := range a {
+				a[i] = make([]float64, k)
+			}
+			b := make([]float64, k)
+
+			for row := range rows {
+				// A = YtY + Yt(Cu - I)Y + λI, b = Yt Cu p(u)
+				for i := 0; i < k; i++ {
+					copy(a[i], gram[i])
+					a[i][i] += config.Lambda
+					b[i] = 0
+				}
+				for _, entry := range entries[row] {
+					y := fixed[entry.index]
+					confidence := 1 + config.Alpha*entry.value
+					for i := 0; i < k; i++ {
+						b[i] += confidence * y[i]
+						for j := 0; j < k; j++ {
+							a[i][j] += (confidence - 1) * y[i] * y[j]
+						}
+					}
+				}
+				solveCholesky(a, b, target[row])
+			}
+		}()
+	}
+
+	for row := range target {
+		rows <- row
+	}
+	close(rows)
+	wg.Wait()
+}
+
+// gramMatrix YtY を計算
+func gramMatrix(factors [][]float64, k int) [][]float64 {
+	gram := make([][]float64, k)
+	for i := range gram {
+		gram[i] = make([]float64, k)
+	}
+	for _, y := range factors {
+		for i := 0; i < k; i++ {
+			for j := 0; j < k; j++ {
+				gram[i][j] += y[i] * y[j]
+			}
+		}
+	}
+	return gram
+}
+
+// solveCholesky 対称正定値行列 a について a x = b を解いて out に書き込む（a は破壊される）
+func solveCholesky(a [][]float64, b, out []float64) {
+	n := len(b)
+
+	// a = L Lt（Lをaの下三角に格納）
+	for j := 0; j < n; j++ {
+		sum := a[j][j]
+		for k := 0; k < j; k++ {
+			sum -= a[j][k] * a[j][k]
+		}
+		if sum <= 0 {
+			sum = 1e-10 // 数値誤差対策
+		}
+		a[j][j] = math.Sqrt(sum)
+		for i := j + 1; i < n; i++ {
+			s := a[i][j]
+			for k := 0; k < j; k++ {
+				s -= a[i][k] * a[j][k]
+			}
+			a[i][j] = s / a[j][j]
+		}
+	}
+
+	// L z = b
+	for i := 0; i < n; i++ {
+		s := b[i]
+		for k := 0; k < i; k++ {
+			s -= a[i][k] * out[k]
+		}
+		out[i] = s / a[i][i]
+	}
+
+	// Lt x = z
+	for i := n - 1; i >= 0; i-- {
+		s := out[i]
+		for k := i + 1; k < n; k++ {
+			s -= a[k][i] * out[k]
+		}
+		out[i] = s / a[i][i]
+	}
+}
+
+// randomFactors 小さな乱数で因子を初期化
+func randomFactors(rng *rand.Rand, rows, k int) [][]float64 {
+	factors := make([][]float64, rows)
+	for i := range factors {
+		factors[i] = make([]float64, k)
+		for j := range factors[i] {
+			factors[i][j] = rng.NormFloat64() * 0.01
+		}
+	}
+	return factors
+}
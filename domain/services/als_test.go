@@ -0,0 +1,52 @@
+package services
+
+import (
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestTrainImplicitALS(t *testing.T) {
+	playedAt := time.Now()
+	var playbacks []*entities.PlaybackHistory
+	// ユーザー1-3はコンテンツ1-3、ユーザー4-6はコンテンツ4-6を聴く2つのクラスタ
+	for userID := 1; userID <= 6; userID++ {
+		base := 1
+		if userID > 3 {
+			base = 4
+		}
+		for contentID := base; contentID < base+3; contentID++ {
+			if userID == 1 && contentID == 3 {
+				continue // ユーザー1は3をまだ聴いていない
+			}
+			playbacks = append(playbacks, &entities.PlaybackHistory{
+				UserID:         userID,
+				AudioContentID: contentID,
+				PlayedAt:       playedAt,
+				Completed:      true,
+			})
+		}
+	}
+
+	config := DefaultALSConfig()
+	config.Factors = 4
+	model, err := TrainImplicitALS(playbacks, config)
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	if len(model.UserIDs) != 6 || len(model.ItemIDs) != 6 {
+		t.Errorf("6ユーザー・6アイテムを期待しましたが、%dユーザー・%dアイテムを取得しました", len(model.UserIDs), len(model.ItemIDs))
+	}
+
+	items := model.RecommendForUser(1, map[int]bool{1: true, 2: true}, 1)
+	if len(items) != 1 || items[0].AudioContentID != 3 {
+		t.Errorf("同じクラスタのコンテンツ3を期待しましたが、%v を取得しました", items)
+	}
+}
+
+func TestTrainImplicitALS_NoInteractions(t *testing.T) {
+	if _, err := TrainImplicitALS(nil, DefaultALSConfig()); err != ErrNoInteractions {
+		t.Errorf("ErrNoInteractionsを期待しましたが、%vを取得しました", err)
+	}
+}
@@ -0,0 +1,189 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sort"
+)
+
+// BecauseYouListenedConfig 「〜を聴いたあなたに」の設定
+type BecauseYouListenedConfig struct {
+	Seeds       int // シードにする直近のコンテンツ数
+	HistoryDays int // シードを探す再生履歴の期間（日）
+}
+
+// DefaultBecauseYouListenedConfig デフォルト設定
+func DefaultBecauseYouListenedConfig() BecauseYouListenedConfig {
+	return BecauseYouListenedConfig{
+		Seeds:       3,
+		HistoryDays: 30,
+	}
+}
+
+// BecauseYouListenedService 最近よく聴いたコンテンツをシードに、シードごとの類似コンテンツを「〜を聴いたあなたに」として返すドメインサービス
+// シードは最後まで（または FullListenRatio 以上）聴いたコンテンツで、類似コンテンツは共聴と同カテゴリ・同作者から選ぶ
+type BecauseYouListenedService struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	similar          *SimilarContentService
+	dismissals       *DismissalService
+	config           BecauseYouListenedConfig
+}
+
+// NewBecauseYouListenedService コンストラクタ
+func NewBecauseYouListenedService(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	similar *SimilarContentService,
+	dismissals *DismissalService,
+	config BecauseYouListenedConfig,
+) *BecauseYouListenedService {
+	return &BecauseYouListenedService{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		similar:          similar,
+		dismissals:       dismissals,
+		config:           config,
+	}
+}
+
+// Shelves シードごとに類似コンテンツ（未再生のみ）の棚を、シードを最近聴いた順に返す
+func (s *BecauseYouListenedService) Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error) {
+	playbacks, err := s.playbackRepo.GetRecentPlaybacks(ctx, userID, s.config.HistoryDays)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds, err := s.seeds(ctx, userID, playbacks)
+	if err != nil {
+		return nil, err
+	}
+	if len(seeds) == 0 {
+		return []*entities.Shelf{}, nil
+	}
+
+	watched := make([]int, 0, len(playbacks))
+	for _, playback := range playbacks {
+		watched = append(watched, playback.AudioContentID)
+	}
+
+	shelves := make([]*entities.Shelf, 0, len(seeds))
+	for _, seed := range seeds {
+		similar, err := s.similar.FindSimilar(ctx, seed, watched, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		recs := make([]*entities.Recommendation, 0, len(similar))
+		for _, sc := range similar {
+			recs = append(recs, &entities.Recommendation{
+				UserID:         userID,
+				AudioContentID: sc.Content.ID,
+				Score:          sc.Score,
+				Reason:         entities.ReasonBecauseYouListened,
+				SeedContentIDs: []int{seed.ID},
+			})
+		}
+
+		shelves = append(shelves, &entities.Shelf{
+			ID:             fmt.Sprintf("%s:%d", entities.ShelfBecauseYouListened, seed.ID),
+			Type:           entities.ShelfBecauseYouListened,
+			Title:          fmt.Sprintf("「%s」を聴いたあなたに", seed.Title),
+			Explanation:    fmt.Sprintf("最近最後まで聴いた「%s」と一緒に聴かれている、または同じカテゴリ・作者のコンテンツ", seed.Title),
+			SeedContentIDs: []int{seed.ID},
+			Items:          recs,
+		})
+	}
+	return shelves, nil
+}
+
+// seeds 最後まで聴いたコンテンツを最近聴いた順に重複なく Seeds 件（興味なしにしたコンテンツ・作者は除く）
+func (s *BecauseYouListenedService) seeds(ctx context.Context, userID int, playbacks []*entities.PlaybackHistory) ([]*entities.AudioContent, error) {
+	strong := make([]*entities.PlaybackHistory, 0, len(playbacks))
+	for _, playback := range playbacks {
+		if playback.BaseEngagementScore() >= entities.FullListenScore {
+			strong = append(strong, playback)
+		}
+	}
+	sort.SliceStable(strong, func(i, j int) bool {
+		return strong[i].PlayedAt.After(strong[j].PlayedAt)
+	})
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, playback := range strong {
+		if !seen[playback.AudioContentID] {
+			seen[playback.AudioContentID] = true
+			ids = append(ids, playback.AudioContentID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	contents, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	dismissed, err := s.dismissals.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	var seeds []*entities.AudioContent
+	for _, id := range ids {
+		content, ok := contentByID[id]
+		if !ok || dismissed.Suppresses(id, content) {
+			continue
+		}
+		seeds = append(seeds, content)
+		if len(seeds) >= s.config.Seeds {
+			break
+		}
+	}
+	return seeds, nil
+}
+
+// Name ソース名
+func (s *BecauseYouListenedService) Name() string {
+	return SourceBecauseYouListened
+}
+
+// Generate シードごとの類似コンテンツをまとめた候補（複数のシードに似ていれば最大のスコア）
+func (s *BecauseYouListenedService) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	shelves, err := s.Shelves(ctx, req.UserID, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	byContent := make(map[int]*entities.Recommendation)
+	var recommendations []*entities.Recommendation
+	for _, shelf := range shelves {
+		for _, rec := range shelf.Items {
+			existing, ok := byContent[rec.AudioContentID]
+			if !ok {
+				byContent[rec.AudioContentID] = rec
+				recommendations = append(recommendations, rec)
+				continue
+			}
+			if rec.Score > existing.Score {
+				existing.Score = rec.Score
+				existing.SeedContentIDs = rec.SeedContentIDs
+			}
+		}
+	}
+
+	sort.SliceStable(recommendations, func(i, j int) bool {
+		return recommendations[i].Score > recommendations[j].Score
+	})
+	if len(recommendations) > req.Limit {
+		recommendations = recommendations[:req.Limit]
+	}
+	return recommendations, nil
+}
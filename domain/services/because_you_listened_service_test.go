@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+func newBecauseYouListenedFixture() *BecauseYouListenedService {
+	now := time.Now()
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1:  {ID: 1, Title: "朝のニュース", CategoryID: 10, AuthorID: 100},
+			2:  {ID: 2, Title: "歴史の話", CategoryID: 20, AuthorID: 200},
+			3:  {ID: 3, Title: "早期スキップ", CategoryID: 30, AuthorID: 300},
+			4:  {ID: 4, Title: "興味なしの作者", CategoryID: 10, AuthorID: 400},
+			20: {ID: 20, CategoryID: 10, AuthorID: 100},
+			21: {ID: 21, CategoryID: 20, AuthorID: 200},
+			22: {ID: 22, CategoryID: 10, AuthorID: 500},
+		},
+		similar: []*entities.AudioContent{
+			{ID: 20, CategoryID: 10, AuthorID: 100},
+			{ID: 2, CategoryID: 20, AuthorID: 200}, // 再生済み
+			{ID: 21, CategoryID: 20, AuthorID: 200},
+		},
+	}
+	playbackRepo := &mockPlaybackRepository{
+		history: map[int][]*entities.PlaybackHistory{
+			1: {
+				{UserID: 1, AudioContentID: 2, PlayedAt: now.Add(-48 * time.Hour), Completed: true},
+				{UserID: 1, AudioContentID: 1, PlayedAt: now.Add(-2 * time.Hour), Completed: true},
+				{UserID: 1, AudioContentID: 3, PlayedAt: now.Add(-time.Hour), Duration: 5, ContentDuration: 600},
+				{UserID: 1, AudioContentID: 4, PlayedAt: now, Completed: true},
+			},
+		},
+		coListened: []*entities.CoListenedContent{
+			{AudioContentID: 22, ListenerCount: 5},
+		},
+	}
+	dismissalRepo := &mockDismissalRepository{
+		dismissals: []*entities.Dismissal{
+			{UserID: 1, TargetType: entities.DismissalTargetAuthor, TargetID: 400},
+		},
+	}
+
+	return NewBecauseYouListenedService(
+		contentRepo,
+		playbackRepo,
+		NewSimilarContentService(contentRepo, playbackRepo),
+		NewDismissalService(dismissalRepo, contentRepo),
+		DefaultBecauseYouListenedConfig(),
+	)
+}
+
+func TestBecauseYouListenedService_Shelves(t *testing.T) {
+	service := newBecauseYouListenedFixture()
+
+	shelves, err := service.Shelves(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 早期スキップ・興味なしの作者はシードにせず、最近聴いた順に並べる
+	expectedTitles := []string{"「朝のニュース」を聴いたあなたに", "「歴史の話」を聴いたあなたに"}
+	if len(shelves) != len(expectedTitles) {
+		t.Fatalf("%d個の棚を期待しましたが、%d個を取得しました", len(expectedTitles), len(shelves))
+	}
+	for i, shelf := range shelves {
+		if shelf.Title != expectedTitles[i] {
+			t.Errorf("インデックス %d: タイトル %s を期待しましたが、%s を取得しました", i, expectedTitles[i], shelf.Title)
+		}
+		if shelf.Type != entities.ShelfBecauseYouListened {
+			t.Errorf("インデックス %d: 種類 %s を期待しましたが、%s を取得しました", i, entities.ShelfBecauseYouListened, shelf.Type)
+		}
+		if len(shelf.SeedContentIDs) != 1 {
+			t.Fatalf("インデックス %d: シード1件を期待しましたが、%v を取得しました", i, shelf.SeedContentIDs)
+		}
+
+		if len(shelf.Items) == 0 {
+			t.Errorf("インデックス %d: 類似コンテンツを期待しましたが、0件でした", i)
+		}
+		for _, rec := range shelf.Items {
+			if rec.AudioContentID <= 4 {
+				t.Errorf("インデックス %d: 再生済みのコンテンツ %d が含まれています", i, rec.AudioContentID)
+			}
+			if rec.Reason != entities.ReasonBecauseYouListened {
+				t.Errorf("理由 %s を期待しましたが、%s を取得しました", entities.ReasonBecauseYouListened, rec.Reason)
+			}
+			if len(rec.SeedContentIDs) != 1 || rec.SeedContentIDs[0] != shelf.SeedContentIDs[0] {
+				t.Errorf("シード %v を期待しましたが、%v を取得しました", shelf.SeedContentIDs, rec.SeedContentIDs)
+			}
+		}
+	}
+}
+
+func TestBecauseYouListenedService_Generate(t *testing.T) {
+	service := newBecauseYouListenedFixture()
+
+	recs, err := service.Generate(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 複数のシードに似たコンテンツも1件にまとめる
+	seen := make(map[int]bool)
+	for i, rec := range recs {
+		if seen[rec.AudioContentID] {
+			t.Errorf("コンテンツ %d が重複しています", rec.AudioContentID)
+		}
+		seen[rec.AudioContentID] = true
+		if i > 0 && recs[i-1].Score < rec.Score {
+			t.Errorf("スコアの降順を期待しましたが、インデックス %d で逆転しています", i)
+		}
+	}
+	for _, id := range []int{20, 21, 22} {
+		if !seen[id] {
+			t.Errorf("コンテンツ %d を期待しましたが、含まれていません", id)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"mimiru-ai/domain/entities"
+	"sort"
+	"sync"
+	"time"
+)
+
+// SourceConfig ソースごとのブレンド設定
+type SourceConfig struct {
+	Name          string
+	Weight        float64             // ソースのスコアに掛ける重み
+	Quota         float64             // limitに対して要求する候補数の割合
+	Normalization NormalizationMethod // 空ならBlendConfig.Normalizationを使用
+}
+
+// BlendConfig ブレンド設定
+type BlendConfig struct {
+	Sources       []SourceConfig
+	Normalization NormalizationMethod    // 重みを掛ける前の各ソースのスコア正規化方法
+	MergeStrategy entities.MergeStrategy // 複数ソースが同じコンテンツを出した場合の統合方法
+}
+
+// DefaultBlendConfig デフォルトのブレンド設定（重みと候補数はここで一元管理）
+func DefaultBlendConfig() BlendConfig {
+	return BlendConfig{
+		Sources: []SourceConfig{
+			// 協調フィルタリング (40%)
+			{Name: SourceCollaborative, Weight: 0.4, Quota: 0.5},
+			{Name: SourceItemBased, Weight: 0.4, Quota: 0.5},
+			{Name: SourceLatentFactors, Weight: 0.4, Quota: 0.5},
+			// 最近最後まで聴いたコンテンツに似たもの（シードを明示できる）
+			{Name: SourceBecauseYouListened, Weight: 0.3, Quota: 0.3},
+			// コンテンツベース (30%)
+			{Name: SourceContentBased, Weight: 0.3, Quota: 0.3},
+			// 人気度ベース (20%)
+			{Name: SourcePopular, Weight: 0.2, Quota: 0.2},
+			// 急上昇（再生ペースの伸び）
+			{Name: SourceTrending, Weight: 0.15, Quota: 0.2},
+			// セッションの続き（直近30分以内に再生していれば次に聴かれやすいもの）
+			{Name: SourceSession, Weight: 0.3, Quota: 0.2},
+			// 新着コンテンツ (10%)
+			{Name: SourceNewContent, Weight: 0.1, Quota: 0.1},
+			// コールドスタート（再生数に応じて協調フィルタリング・コンテンツベースへ引き継ぐ）
+			{Name: SourceColdStart, Weight: 0.7, Quota: 0.8},
+		},
+		Normalization: NormalizeMinMax,
+		MergeStrategy: entities.MergeSum,
+	}
+}
+
+// WeightModifier リクエストごとにソースの重みを調整する（0以下にしたソースは実行しない）
+type WeightModifier interface {
+	ModifyWeights(ctx context.Context, req *entities.RecommendationRequest, weights map[string]float64) error
+}
+
+// CandidateFilter 各ソースの候補から推薦しないものを除外する（スコアは変えない）
+// 除外した分はソースから取り直すため、除外があってもソースごとの候補数は保たれる
+type CandidateFilter interface {
+	FilterCandidates(ctx context.Context, req *entities.RecommendationRequest, recs []*entities.Recommendation) ([]*entities.Recommendation, error)
+}
+
+// SetAdjuster 正規化・重み付けして統合した後の候補を除外・減点する
+// 正規化の前に減点すると、候補が1件のソースやすべての候補が減点対象のソースでは正規化で元に戻ってしまうため
+type SetAdjuster interface {
+	AdjustSet(ctx context.Context, req *entities.RecommendationRequest, recSet *entities.RecommendationSet) error
+}
+
+// Blender 登録済みソースの候補を重み付けして1つのセットにまとめる
+type Blender struct {
+	registry  *SourceRegistry
+	config    BlendConfig
+	modifiers []WeightModifier
+	filters   []CandidateFilter
+	adjusters []SetAdjuster
+}
+
+// 除外で足りなくなった候補をソースから取り直す回数の上限
+const maxCandidateRefetches = 2
+
+// NewBlender コンストラクタ（設定に未登録のソースがあればエラー）
+func NewBlender(registry *SourceRegistry, config BlendConfig) (*Blender, error) {
+	for _, sc := range config.Sources {
+		if _, ok := registry.Get(sc.Name); !ok {
+			return nil, fmt.Errorf("ソース %q が登録されていません", sc.Name)
+		}
+	}
+
+	return &Blender{
+		registry: registry,
+		config:   config,
+	}, nil
+}
+
+// AddWeightModifier 重みの調整を追加（追加順に適用）
+func (b *Blender) AddWeightModifier(modifier WeightModifier) {
+	b.modifiers = append(b.modifiers, modifier)
+}
+
+// AddFilter ソースごとの候補の除外を追加（追加順に適用）
+func (b *Blender) AddFilter(filter CandidateFilter) {
+	b.filters = append(b.filters, filter)
+}
+
+// AddAdjuster 統合後の候補の調整を追加（追加順に適用）
+func (b *Blender) AddAdjuster(adjuster SetAdjuster) {
+	b.adjusters = append(b.adjusters, adjuster)
+}
+
+// weightsFor リクエストに適用するソースごとの重み（調整に失敗した場合はその調整をスキップ）
+func (b *Blender) weightsFor(ctx context.Context, req *entities.RecommendationRequest) map[string]float64 {
+	weights := make(map[string]float64, len(b.config.Sources))
+	for _, sc := range b.config.Sources {
+		weights[sc.Name] = sc.Weight
+	}
+
+	for _, modifier := range b.modifiers {
+		adjusted := make(map[string]float64, len(weights))
+		for name, weight := range weights {
+			adjusted[name] = weight
+		}
+		if err := modifier.ModifyWeights(ctx, req, adjusted); err != nil {
+			continue
+		}
+		weights = adjusted
+	}
+	return weights
+}
+
+// Blend 各ソースを並行実行し、正規化・重み付けした候補をコンテンツごとに統合してから調整する
+// 失敗したソースはスキップし、除外・調整の失敗はエラーにする（興味なしの除外などを素通りさせない）
+func (b *Blender) Blend(ctx context.Context, req *entities.RecommendationRequest) (*entities.RecommendationSet, error) {
+	// 重みの調整・各ソース・統合後の調整で取得結果を共有する
+	if req.Memo == nil {
+		withMemo := *req
+		withMemo.Memo = entities.NewRequestMemo()
+		req = &withMemo
+	}
+
+	weights := b.weightsFor(ctx, req)
+	results := make([][]*entities.Recommendation, len(b.config.Sources))
+	filterErrs := make([]error, len(b.config.Sources))
+
+	var wg sync.WaitGroup
+	for i, sc := range b.config.Sources {
+		source, _ := b.registry.Get(sc.Name)
+		quota := quotaFor(req.Limit, sc.Quota)
+		if quota == 0 || weights[sc.Name] <= 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, source RecommendationSource) {
+			defer wg.Done()
+			results[i], filterErrs[i] = b.generate(ctx, req, source, quota)
+		}(i, source)
+	}
+	wg.Wait()
+
+	for _, err := range filterErrs {
+		if err != nil {
+			return nil, fmt.Errorf("候補の除外に失敗しました: %w", err)
+		}
+	}
+
+	now := time.Now()
+	recSet := &entities.RecommendationSet{
+		UserID:      req.UserID,
+		GeneratedAt: now,
+	}
+	for i, recs := range results {
+		sc := b.config.Sources[i]
+		weight := weights[sc.Name]
+
+		rawScores := make([]float64, len(recs))
+		for j, rec := range recs {
+			rawScores[j] = rec.Score
+		}
+
+		// スケールの異なるソースを揃えてから重みを掛ける
+		NormalizeScores(recs, b.normalizationFor(sc))
+		for j, rec := range recs {
+			normalized := rec.Score
+			rec.Score *= weight
+			rec.Contributions = []*entities.SourceContribution{{
+				Source:          sc.Name,
+				Reason:          rec.Reason,
+				RawScore:        rawScores[j],
+				NormalizedScore: normalized,
+				Weight:          weight,
+				Score:           rec.Score,
+				SeedContentIDs:  rec.SeedContentIDs,
+				SimilarUserIDs:  rec.SimilarUserIDs,
+			}}
+			rec.GeneratedAt = now
+			recSet.AddRecommendation(rec)
+		}
+	}
+	recSet.MergeDuplicates(b.config.MergeStrategy)
+
+	for _, adjuster := range b.adjusters {
+		if err := adjuster.AdjustSet(ctx, req, recSet); err != nil {
+			return nil, fmt.Errorf("候補の調整に失敗しました: %w", err)
+		}
+	}
+
+	return recSet, nil
+}
+
+// generate ソースの候補から除外したうえでquota件を返す（ソースの失敗はnil、除外の失敗はエラー）
+// 除外した件数だけ多く要求して取り直し、除外のためにソースの候補数が減らないようにする
+func (b *Blender) generate(
+	ctx context.Context,
+	req *entities.RecommendationRequest,
+	source RecommendationSource,
+	quota int,
+) ([]*entities.Recommendation, error) {
+	limit := quota
+	for attempt := 0; ; attempt++ {
+		recs, err := source.Generate(ctx, &entities.RecommendationRequest{
+			UserID: req.UserID,
+			Limit:  limit,
+			Memo:   req.Memo,
+		})
+		if err != nil {
+			return nil, nil
+		}
+
+		kept := recs
+		for _, filter := range b.filters {
+			if kept, err = filter.FilterCandidates(ctx, req, kept); err != nil {
+				return nil, err
+			}
+		}
+
+		// ソースの候補が尽きたか、上限まで取り直したらあるだけ返す
+		removed := len(recs) - len(kept)
+		if len(kept) >= quota || removed == 0 || len(recs) < limit || attempt >= maxCandidateRefetches {
+			sort.SliceStable(kept, func(i, j int) bool {
+				return kept[i].Score > kept[j].Score
+			})
+			if len(kept) > quota {
+				kept = kept[:quota]
+			}
+			return kept, nil
+		}
+		limit += removed
+	}
+}
+
+// normalizationFor ソースに適用する正規化方法
+func (b *Blender) normalizationFor(sc SourceConfig) NormalizationMethod {
+	if sc.Normalization != "" {
+		return sc.Normalization
+	}
+	return b.config.Normalization
+}
+
+// quotaFor limitと割合からソースに要求する候補数を計算
+func quotaFor(limit int, ratio float64) int {
+	if ratio <= 0 || limit <= 0 {
+		return 0
+	}
+	return int(math.Ceil(float64(limit) * ratio))
+}
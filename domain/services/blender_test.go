@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type stubSource struct {
+	name         string
+	recs         []*entities.Recommendation
+	err          error
+	respectLimit bool // 要求された件数までに絞る
+	lastLimit    int
+}
+
+func (s *stubSource) Name() string {
+	return s.name
+}
+
+func (s *stubSource) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	s.lastLimit = req.Limit
+	if s.err != nil {
+		return nil, s.err
+	}
+	if s.respectLimit && len(s.recs) > req.Limit {
+		return s.recs[:req.Limit], nil
+	}
+	return s.recs, nil
+}
+
+func TestBlender_Blend(t *testing.T) {
+	collaborative := &stubSource{
+		name: "a",
+		recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 10, Score: 2.0}},
+	}
+	popular := &stubSource{
+		name: "b",
+		recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 20, Score: 10.0}},
+	}
+	failing := &stubSource{name: "c", err: errors.New("DBエラー")}
+
+	registry := NewSourceRegistry()
+	for _, source := range []RecommendationSource{collaborative, popular, failing} {
+		if err := registry.Register(source); err != nil {
+			t.Fatalf("登録に失敗しました: %v", err)
+		}
+	}
+
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources: []SourceConfig{
+			{Name: "a", Weight: 0.5, Quota: 0.5},
+			{Name: "b", Weight: 0.1, Quota: 0.25},
+			{Name: "c", Weight: 1.0, Quota: 1.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	recSet, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 候補数はlimit × 割合（切り上げ）
+	if collaborative.lastLimit != 5 || popular.lastLimit != 3 {
+		t.Errorf("候補数 5, 3 を期待しましたが、%d, %d を取得しました", collaborative.lastLimit, popular.lastLimit)
+	}
+
+	// 失敗したソースはスキップされ、重みが掛かる
+	if len(recSet.Recommendations) != 2 {
+		t.Fatalf("2件の候補を期待しましたが、%d件を取得しました", len(recSet.Recommendations))
+	}
+	scores := map[int]float64{}
+	for _, rec := range recSet.Recommendations {
+		scores[rec.AudioContentID] = rec.Score
+	}
+	if scores[10] != 1.0 || scores[20] != 1.0 {
+		t.Errorf("重み付け後のスコア 1.0, 1.0 を期待しましたが、%v を取得しました", scores)
+	}
+}
+
+func TestNewBlender_UnknownSource(t *testing.T) {
+	_, err := NewBlender(NewSourceRegistry(), BlendConfig{
+		Sources: []SourceConfig{{Name: "unknown", Weight: 1.0, Quota: 1.0}},
+	})
+	if err == nil {
+		t.Error("未登録ソースに対するエラーを期待しましたが、エラーがありませんでした")
+	}
+}
+
+func TestSourceRegistry_RegisterDuplicate(t *testing.T) {
+	registry := NewSourceRegistry()
+	if err := registry.Register(&stubSource{name: "a"}); err != nil {
+		t.Fatalf("登録に失敗しました: %v", err)
+	}
+	if err := registry.Register(&stubSource{name: "a"}); err == nil {
+		t.Error("同名ソースの登録に対するエラーを期待しましたが、エラーがありませんでした")
+	}
+}
+
+func TestTopRecommendations(t *testing.T) {
+	recs := []*entities.Recommendation{
+		{AudioContentID: 3, Score: 0.5},
+		{AudioContentID: 2, Score: 0.9},
+		{AudioContentID: 4, Score: 0.9},
+		{AudioContentID: 1, Score: 0.1},
+	}
+
+	// 生成順ではなくスコアの上位（同点はコンテンツID順）
+	top := topRecommendations(recs, 3)
+	expectedIDs := []int{2, 4, 3}
+	if len(top) != len(expectedIDs) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expectedIDs), len(top))
+	}
+	for i, rec := range top {
+		if rec.AudioContentID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツ %d を期待しましたが、%d を取得しました", i, expectedIDs[i], rec.AudioContentID)
+		}
+	}
+}
+
+func TestBlender_BlendRecordsContributions(t *testing.T) {
+	itemBased := &stubSource{
+		name: "a",
+		recs: []*entities.Recommendation{
+			{UserID: 1, AudioContentID: 10, Score: 4.0, Reason: entities.ReasonSimilarItems, SeedContentIDs: []int{1, 2}},
+			{UserID: 1, AudioContentID: 11, Score: 2.0, Reason: entities.ReasonSimilarItems, SeedContentIDs: []int{1}},
+		},
+	}
+	collaborative := &stubSource{
+		name: "b",
+		recs: []*entities.Recommendation{
+			{UserID: 1, AudioContentID: 10, Score: 3.0, Reason: entities.ReasonSimilarUsers, SimilarUserIDs: []int{7}},
+		},
+	}
+
+	registry := NewSourceRegistry()
+	for _, source := range []RecommendationSource{itemBased, collaborative} {
+		if err := registry.Register(source); err != nil {
+			t.Fatalf("登録に失敗しました: %v", err)
+		}
+	}
+
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources: []SourceConfig{
+			{Name: "a", Weight: 0.5, Quota: 1.0},
+			{Name: "b", Weight: 0.2, Quota: 1.0},
+		},
+		Normalization: NormalizeMinMax,
+		MergeStrategy: entities.MergeSum,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	recSet, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	var merged *entities.Recommendation
+	for _, rec := range recSet.Recommendations {
+		if rec.AudioContentID == 10 {
+			merged = rec
+		}
+	}
+	if merged == nil {
+		t.Fatal("コンテンツ10が見つかりません")
+	}
+
+	if len(merged.Contributions) != 2 {
+		t.Fatalf("2件の寄与を期待しましたが、%d件を取得しました", len(merged.Contributions))
+	}
+	first := merged.Contributions[0]
+	if first.Source != "a" || first.RawScore != 4.0 || first.NormalizedScore != 1.0 || first.Weight != 0.5 || first.Score != 0.5 {
+		t.Errorf("ソースaの寄与 (4.0, 1.0, 0.5, 0.5) を期待しましたが、%+v を取得しました", first)
+	}
+	if merged.Contributions[1].Source != "b" || len(merged.Contributions[1].SimilarUserIDs) != 1 {
+		t.Errorf("類似ユーザー付きのソースbの寄与を期待しましたが、%+v を取得しました", merged.Contributions[1])
+	}
+	if len(merged.SeedContentIDs) != 2 || len(merged.SimilarUserIDs) != 1 {
+		t.Errorf("シード2件・類似ユーザー1件を期待しましたが、%v, %v を取得しました", merged.SeedContentIDs, merged.SimilarUserIDs)
+	}
+	if len(merged.Adjustments) != 1 || merged.Adjustments[0].Type != entities.AdjustmentMerged {
+		t.Errorf("統合の記録を期待しましたが、%v を取得しました", merged.Adjustments)
+	}
+}
+
+type stubWeightModifier struct {
+	weights map[string]float64
+}
+
+func (m *stubWeightModifier) ModifyWeights(ctx context.Context, req *entities.RecommendationRequest, weights map[string]float64) error {
+	for name, weight := range m.weights {
+		weights[name] = weight
+	}
+	return nil
+}
+
+func TestBlender_WeightModifier(t *testing.T) {
+	boosted := &stubSource{
+		name: "a",
+		recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 10, Score: 2.0}},
+	}
+	disabled := &stubSource{
+		name: "b",
+		recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 20, Score: 2.0}},
+	}
+
+	registry := NewSourceRegistry()
+	for _, source := range []RecommendationSource{boosted, disabled} {
+		if err := registry.Register(source); err != nil {
+			t.Fatalf("登録に失敗しました: %v", err)
+		}
+	}
+
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources: []SourceConfig{
+			{Name: "a", Weight: 0.5, Quota: 1.0},
+			{Name: "b", Weight: 0.5, Quota: 1.0},
+		},
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	blender.AddWeightModifier(&stubWeightModifier{weights: map[string]float64{"a": 0.9, "b": 0}})
+
+	recSet, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 重み0のソースは実行されない
+	if disabled.lastLimit != 0 {
+		t.Errorf("ソースbが実行されないことを期待しましたが、候補数 %d で実行されました", disabled.lastLimit)
+	}
+	if len(recSet.Recommendations) != 1 {
+		t.Fatalf("1件の候補を期待しましたが、%d件を取得しました", len(recSet.Recommendations))
+	}
+	if score := recSet.Recommendations[0].Score; score != 1.8 {
+		t.Errorf("調整後の重み 0.9 が掛かったスコア 1.8 を期待しましたが、%v を取得しました", score)
+	}
+}
+
+type stubAdjuster struct {
+	err error
+}
+
+func (a *stubAdjuster) AdjustSet(ctx context.Context, req *entities.RecommendationRequest, recSet *entities.RecommendationSet) error {
+	return a.err
+}
+
+func TestBlender_AdjusterError(t *testing.T) {
+	registry := NewSourceRegistry()
+	source := &stubSource{name: "a", recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 10, Score: 1.0}}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("登録に失敗しました: %v", err)
+	}
+	blender, err := NewBlender(registry, BlendConfig{Sources: []SourceConfig{{Name: "a", Weight: 1.0, Quota: 1.0}}})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	blender.AddAdjuster(&stubAdjuster{err: errors.New("興味なしの取得に失敗")})
+
+	// 興味なしの除外を素通りさせないため、調整の失敗はエラーにする
+	if _, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}); err == nil {
+		t.Error("エラーを期待しましたが、nilを取得しました")
+	}
+}
+
+type stubFilter struct {
+	err error
+}
+
+func (f *stubFilter) FilterCandidates(ctx context.Context, req *entities.RecommendationRequest, recs []*entities.Recommendation) ([]*entities.Recommendation, error) {
+	return recs, f.err
+}
+
+func TestBlender_FilterError(t *testing.T) {
+	registry := NewSourceRegistry()
+	source := &stubSource{name: "a", recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 10, Score: 1.0}}}
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("登録に失敗しました: %v", err)
+	}
+	blender, err := NewBlender(registry, BlendConfig{Sources: []SourceConfig{{Name: "a", Weight: 1.0, Quota: 1.0}}})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	blender.AddFilter(&stubFilter{err: errors.New("興味なしの取得に失敗")})
+
+	// ソースの失敗と違い、除外の失敗はスキップせずエラーにする
+	if _, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}); err == nil {
+		t.Error("エラーを期待しましたが、nilを取得しました")
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// ColdStartConfig コールドスタートの設定
+type ColdStartConfig struct {
+	HandoverPlays     int                  // この再生数に達すると行動ベースのソースに完全に引き継ぐ
+	BehavioralSources []string             // 再生数に応じて重みを上げていくソース
+	PopularDays       int                  // 人気コンテンツの集計期間（日）
+	NewContentDays    int                  // 新着コンテンツとみなす期間（日）
+	Decay             entities.DecayConfig // 新着コンテンツの鮮度の減衰
+}
+
+// DefaultColdStartConfig デフォルト設定
+func DefaultColdStartConfig() ColdStartConfig {
+	return ColdStartConfig{
+		HandoverPlays:     20,
+		BehavioralSources: []string{SourceCollaborative, SourceItemBased, SourceLatentFactors, SourceContentBased},
+		PopularDays:       30,
+		NewContentDays:    14,
+		Decay:             entities.DefaultDecayConfig(),
+	}
+}
+
+// ColdStartService オンボーディングで選択したカテゴリから候補を出すコールドスタートのソース
+// 再生履歴が増えるにつれて自身の重みを下げ、行動ベースのソースの重みを上げる
+type ColdStartService struct {
+	userRepo         repositories.UserRepository
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	config           ColdStartConfig
+}
+
+// NewColdStartService コンストラクタ
+func NewColdStartService(
+	userRepo repositories.UserRepository,
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	config ColdStartConfig,
+) *ColdStartService {
+	return &ColdStartService{
+		userRepo:         userRepo,
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		config:           config,
+	}
+}
+
+// Name ソース名
+func (s *ColdStartService) Name() string {
+	return SourceColdStart
+}
+
+// Generate 選択カテゴリの人気・新着コンテンツから未再生のものを候補にする
+func (s *ColdStartService) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	categories, history, err := s.load(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if len(categories) == 0 || s.handoverProgress(history) >= 1 {
+		return []*entities.Recommendation{}, nil
+	}
+
+	popular, err := s.audioContentRepo.GetPopularContentInCategories(ctx, categories, s.config.PopularDays, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+	newContent, err := s.audioContentRepo.GetNewContentInCategories(ctx, categories, s.config.NewContentDays, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	for _, h := range history {
+		seen[h.AudioContentID] = true
+	}
+
+	var recommendations []*entities.Recommendation
+	add := func(content *entities.AudioContent, score float64) {
+		if seen[content.ID] || len(recommendations) >= req.Limit {
+			return
+		}
+		seen[content.ID] = true
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         req.UserID,
+			AudioContentID: content.ID,
+			Score:          score,
+			Reason:         entities.ReasonPreferredCategory,
+		})
+	}
+
+	// 人気コンテンツは順位に応じて(1, 2]、新着は鮮度に応じて(0, 1]で交互に並べる
+	now := time.Now()
+	for i := 0; i < len(popular) || i < len(newContent); i++ {
+		if i < len(popular) {
+			add(popular[i], 1+float64(len(popular)-i)/float64(len(popular)))
+		}
+		if i < len(newContent) {
+			add(newContent[i], newContent[i].Freshness(s.config.Decay, now))
+		}
+	}
+
+	return recommendations, nil
+}
+
+// ModifyWeights 再生数に応じてコールドスタートから行動ベースのソースへ重みを移す
+func (s *ColdStartService) ModifyWeights(ctx context.Context, req *entities.RecommendationRequest, weights map[string]float64) error {
+	categories, history, err := s.load(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	// カテゴリ未選択のユーザーは従来どおり
+	if len(categories) == 0 {
+		weights[SourceColdStart] = 0
+		return nil
+	}
+
+	progress := s.handoverProgress(history)
+	weights[SourceColdStart] *= 1 - progress
+	for _, name := range s.config.BehavioralSources {
+		if _, ok := weights[name]; ok {
+			weights[name] *= progress
+		}
+	}
+	return nil
+}
+
+// coldStartMemoKey 取得結果をリクエストのメモに保存するキー
+const coldStartMemoKey = "cold_start"
+
+// coldStartData 重みの調整と候補生成で共有する取得結果
+type coldStartData struct {
+	categories []int
+	history    []*entities.PlaybackHistory
+}
+
+// load 選択カテゴリと引き継ぎ判定に必要な分の再生履歴を取得（同じリクエスト内では1回だけ問い合わせる）
+func (s *ColdStartService) load(ctx context.Context, req *entities.RecommendationRequest) ([]int, []*entities.PlaybackHistory, error) {
+	value, err := req.Memo.Load(coldStartMemoKey, func() (interface{}, error) {
+		categories, history, err := s.fetch(ctx, req.UserID)
+		if err != nil {
+			return nil, err
+		}
+		return &coldStartData{categories: categories, history: history}, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	data := value.(*coldStartData)
+	return data.categories, data.history, nil
+}
+
+// fetch 選択カテゴリと再生履歴をリポジトリから取得
+func (s *ColdStartService) fetch(ctx context.Context, userID int) ([]int, []*entities.PlaybackHistory, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if user == nil || len(user.PreferredCategories) == 0 {
+		return nil, nil, nil
+	}
+
+	history, err := s.playbackRepo.GetUserHistory(ctx, userID, s.config.HandoverPlays)
+	if err != nil {
+		return nil, nil, err
+	}
+	return user.PreferredCategories, history, nil
+}
+
+// handoverProgress 行動ベースへの引き継ぎ度合い（0: コールドスタートのみ 〜 1: 完全に引き継ぎ）
+func (s *ColdStartService) handoverProgress(history []*entities.PlaybackHistory) float64 {
+	if s.config.HandoverPlays <= 0 {
+		return 1
+	}
+	return math.Min(float64(len(history))/float64(s.config.HandoverPlays), 1)
+}
@@ -0,0 +1,166 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockUserRepository struct {
+	users map[int]*entities.User
+	calls int
+}
+
+func (m *mockUserRepository) GetByID(ctx context.Context, userID int) (*entities.User, error) {
+	m.calls++
+	return m.users[userID], nil
+}
+
+func (m *mockUserRepository) GetSimilarUsers(ctx context.Context, userID int, limit int) ([]*entities.User, error) {
+	return []*entities.User{}, nil
+}
+
+func (m *mockUserRepository) Save(ctx context.Context, user *entities.User) error {
+	return nil
+}
+
+func (m *mockUserRepository) SavePreferredCategories(ctx context.Context, userID int, categoryIDs []int) error {
+	return nil
+}
+
+func newColdStartFixture(plays int) *ColdStartService {
+	userRepo := &mockUserRepository{
+		users: map[int]*entities.User{
+			1: {ID: 1, Email: "new@example.com", PreferredCategories: []int{10}},
+			2: {ID: 2, Email: "skip@example.com"},
+		},
+	}
+	contentRepo := &mockAudioContentRepository{
+		popular: []*entities.AudioContent{
+			{ID: 1, CategoryID: 10},
+			{ID: 2, CategoryID: 20}, // 選択外のカテゴリ
+			{ID: 3, CategoryID: 10},
+		},
+		newContent: []*entities.AudioContent{
+			{ID: 3, CategoryID: 10}, // 人気と重複
+			{ID: 4, CategoryID: 10},
+		},
+	}
+
+	var history []*entities.PlaybackHistory
+	for i := 0; i < plays; i++ {
+		history = append(history, &entities.PlaybackHistory{UserID: 1, AudioContentID: 1})
+	}
+	playbackRepo := &mockPlaybackRepository{
+		history: map[int][]*entities.PlaybackHistory{1: history},
+	}
+
+	return NewColdStartService(userRepo, contentRepo, playbackRepo, ColdStartConfig{
+		HandoverPlays:     4,
+		BehavioralSources: []string{SourceCollaborative},
+		PopularDays:       30,
+		NewContentDays:    14,
+	})
+}
+
+func TestColdStartService_Generate(t *testing.T) {
+	tests := []struct {
+		name     string
+		userID   int
+		plays    int
+		expected []int
+	}{
+		{name: "新規ユーザーは選択カテゴリの人気・新着", userID: 1, plays: 0, expected: []int{1, 3, 4}},
+		{name: "再生済みのコンテンツは除外", userID: 1, plays: 1, expected: []int{3, 4}},
+		{name: "十分な再生履歴があれば候補なし", userID: 1, plays: 4, expected: []int{}},
+		{name: "カテゴリ未選択なら候補なし", userID: 2, plays: 0, expected: []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newColdStartFixture(tt.plays)
+
+			recs, err := service.Generate(context.Background(), &entities.RecommendationRequest{UserID: tt.userID, Limit: 10})
+			if err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if len(recs) != len(tt.expected) {
+				t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(tt.expected), len(recs))
+			}
+			for i, rec := range recs {
+				if rec.AudioContentID != tt.expected[i] {
+					t.Errorf("インデックス %d: コンテンツ %d を期待しましたが、%d を取得しました", i, tt.expected[i], rec.AudioContentID)
+				}
+				if rec.Reason != entities.ReasonPreferredCategory {
+					t.Errorf("理由 %s を期待しましたが、%s を取得しました", entities.ReasonPreferredCategory, rec.Reason)
+				}
+			}
+		})
+	}
+}
+
+func TestColdStartService_ModifyWeights(t *testing.T) {
+	tests := []struct {
+		name              string
+		userID            int
+		plays             int
+		expectedColdStart float64
+		expectedBehavior  float64
+	}{
+		{name: "再生なし", userID: 1, plays: 0, expectedColdStart: 0.8, expectedBehavior: 0},
+		{name: "引き継ぎ途中", userID: 1, plays: 1, expectedColdStart: 0.6, expectedBehavior: 0.1},
+		{name: "引き継ぎ完了", userID: 1, plays: 4, expectedColdStart: 0, expectedBehavior: 0.4},
+		{name: "カテゴリ未選択", userID: 2, plays: 0, expectedColdStart: 0, expectedBehavior: 0.4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := newColdStartFixture(tt.plays)
+			weights := map[string]float64{SourceColdStart: 0.8, SourceCollaborative: 0.4, SourcePopular: 0.2}
+
+			if err := service.ModifyWeights(context.Background(), &entities.RecommendationRequest{UserID: tt.userID}, weights); err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if math.Abs(weights[SourceColdStart]-tt.expectedColdStart) > 1e-9 {
+				t.Errorf("コールドスタートの重み %v を期待しましたが、%v を取得しました", tt.expectedColdStart, weights[SourceColdStart])
+			}
+			if math.Abs(weights[SourceCollaborative]-tt.expectedBehavior) > 1e-9 {
+				t.Errorf("協調フィルタリングの重み %v を期待しましたが、%v を取得しました", tt.expectedBehavior, weights[SourceCollaborative])
+			}
+			if weights[SourcePopular] != 0.2 {
+				t.Errorf("対象外のソースの重みは変わらないことを期待しましたが、%v を取得しました", weights[SourcePopular])
+			}
+		})
+	}
+}
+
+func TestColdStartService_LoadOncePerRequest(t *testing.T) {
+	service := newColdStartFixture(1)
+	userRepo := service.userRepo.(*mockUserRepository)
+
+	registry := NewSourceRegistry()
+	if err := registry.Register(service); err != nil {
+		t.Fatalf("登録に失敗しました: %v", err)
+	}
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources:       []SourceConfig{{Name: SourceColdStart, Weight: 0.8, Quota: 1.0}},
+		Normalization: NormalizeMinMax,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	blender.AddWeightModifier(service)
+
+	for i := 1; i <= 2; i++ {
+		if _, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}); err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		// 重みの調整と候補生成で取得結果を共有し、リクエストをまたいでは共有しない
+		if userRepo.calls != i {
+			t.Errorf("%d回目のリクエスト後にユーザー取得%d回を期待しましたが、%d回を取得しました", i, i, userRepo.calls)
+		}
+	}
+}
@@ -0,0 +1,169 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+)
+
+// ContextualBoostConfig 時間帯・端末による加減点の設定
+type ContextualBoostConfig struct {
+	HistoryDays    int     // 傾向の学習に使う再生履歴の日数
+	MinSlotPlays   float64 // 時間枠でこれ未満しか聴いていなければ時間帯の補正をしない
+	PriorWeight    float64 // 時間枠内の割合を全体の割合に寄せる平滑化の重み（再生数換算）
+	CategoryWeight float64 // カテゴリの傾向の効き具合（倍率の指数）
+	DurationWeight float64 // 長さの傾向の効き具合（倍率の指数）
+	// 端末ごとの長さの区分の倍率（再生履歴に端末が記録されていないため学習せず固定値）
+	DeviceDurationBoosts map[entities.DeviceType]map[entities.DurationBucket]float64
+	MinBoost             float64
+	MaxBoost             float64
+}
+
+// DefaultContextualBoostConfig デフォルト設定
+func DefaultContextualBoostConfig() ContextualBoostConfig {
+	return ContextualBoostConfig{
+		HistoryDays:    90,
+		MinSlotPlays:   5,
+		PriorWeight:    5,
+		CategoryWeight: 0.5,
+		DurationWeight: 0.5,
+		DeviceDurationBoosts: map[entities.DeviceType]map[entities.DurationBucket]float64{
+			// 運転中・スマートスピーカーは短いクリップ、デスクトップは長尺を聴きやすい
+			entities.DeviceCar:          {entities.DurationShort: 1.2, entities.DurationLong: 0.8},
+			entities.DeviceSmartSpeaker: {entities.DurationShort: 1.15, entities.DurationLong: 0.9},
+			entities.DeviceDesktop:      {entities.DurationLong: 1.1},
+		},
+		MinBoost: 0.7,
+		MaxBoost: 1.5,
+	}
+}
+
+// ContextualBoostService リクエスト時点の時間枠・端末に合うコンテンツを加点し、合わないものを減点するドメインサービス
+// ユーザーが同じ時間枠（平日の朝など）に聴いてきたカテゴリ・長さの傾向を再生履歴から学習し、端末による長さの向き不向きを掛け合わせる
+// 利用状況の指定がないリクエストには何もしない
+type ContextualBoostService struct {
+	playbackRepo     repositories.PlaybackRepository
+	audioContentRepo repositories.AudioContentRepository
+	config           ContextualBoostConfig
+}
+
+// NewContextualBoostService コンストラクタ
+func NewContextualBoostService(
+	playbackRepo repositories.PlaybackRepository,
+	audioContentRepo repositories.AudioContentRepository,
+	config ContextualBoostConfig,
+) *ContextualBoostService {
+	return &ContextualBoostService{
+		playbackRepo:     playbackRepo,
+		audioContentRepo: audioContentRepo,
+		config:           config,
+	}
+}
+
+// Profile ユーザーの時間枠ごとの聴取傾向を利用者のタイムゾーンで集計
+func (s *ContextualBoostService) Profile(ctx context.Context, userID int, listening *entities.ListeningContext) (*entities.ListeningTimeProfile, error) {
+	playbacks, err := s.playbackRepo.GetRecentPlaybacks(ctx, userID, s.config.HistoryDays)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := s.contentsByID(ctx, playbackContentIDs(playbacks))
+	if err != nil {
+		return nil, err
+	}
+
+	return entities.BuildListeningTimeProfile(playbacks, contents, listening.Location()), nil
+}
+
+// Boost 利用状況でのコンテンツの倍率（MinBoost〜MaxBoost）
+func (s *ContextualBoostService) Boost(profile *entities.ListeningTimeProfile, listening *entities.ListeningContext, content *entities.AudioContent) float64 {
+	if content == nil {
+		return 1.0
+	}
+
+	boost := s.DeviceBoost(listening.Device, content)
+	if slot := listening.Slot(); profile.SlotPlays(slot) >= s.config.MinSlotPlays {
+		boost *= math.Pow(profile.CategoryLift(slot, content.CategoryID, s.config.PriorWeight), s.config.CategoryWeight) *
+			math.Pow(profile.DurationLift(slot, content.Duration, s.config.PriorWeight), s.config.DurationWeight)
+	}
+	return math.Max(s.config.MinBoost, math.Min(s.config.MaxBoost, boost))
+}
+
+// DeviceBoost 端末でのコンテンツの長さの倍率（設定がなければ1.0）
+func (s *ContextualBoostService) DeviceBoost(device entities.DeviceType, content *entities.AudioContent) float64 {
+	if boost, ok := s.config.DeviceDurationBoosts[device][entities.DurationBucketOf(content.Duration)]; ok {
+		return boost
+	}
+	return 1.0
+}
+
+// AdjustSet 正規化・重み付けして統合した候補のスコアに時間枠の倍率を掛ける
+func (s *ContextualBoostService) AdjustSet(
+	ctx context.Context,
+	req *entities.RecommendationRequest,
+	recSet *entities.RecommendationSet,
+) error {
+	if req.Context == nil || len(recSet.Recommendations) == 0 {
+		return nil
+	}
+
+	profile, err := s.Profile(ctx, req.UserID, req.Context)
+	if err != nil {
+		return err
+	}
+	slot := req.Context.Slot()
+	if profile.SlotPlays(slot) < s.config.MinSlotPlays && len(s.config.DeviceDurationBoosts[req.Context.Device]) == 0 {
+		return nil
+	}
+
+	// 統合済みのためコンテンツの重複はない
+	ids := make([]int, 0, len(recSet.Recommendations))
+	for _, rec := range recSet.Recommendations {
+		ids = append(ids, rec.AudioContentID)
+	}
+	contents, err := s.contentsByID(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for _, rec := range recSet.Recommendations {
+		boost := s.Boost(profile, req.Context, contents[rec.AudioContentID])
+		if boost == 1.0 {
+			continue
+		}
+		rec.Score *= boost
+		rec.AddAdjustment(entities.AdjustmentTimeContext, "%sの聴取傾向と端末%sに合わせてスコア×%.2f", slot, req.Context.Device, boost)
+	}
+	return nil
+}
+
+// contentsByID コンテンツをIDで引けるように取得
+func (s *ContextualBoostService) contentsByID(ctx context.Context, ids []int) (map[int]*entities.AudioContent, error) {
+	contents := make(map[int]*entities.AudioContent, len(ids))
+	if len(ids) == 0 {
+		return contents, nil
+	}
+
+	list, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, content := range list {
+		contents[content.ID] = content
+	}
+	return contents, nil
+}
+
+// playbackContentIDs 再生履歴のコンテンツID（重複なし）
+func playbackContentIDs(playbacks []*entities.PlaybackHistory) []int {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, playback := range playbacks {
+		if !seen[playback.AudioContentID] {
+			seen[playback.AudioContentID] = true
+			ids = append(ids, playback.AudioContentID)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestContextualBoostService_AdjustSet(t *testing.T) {
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1:  {ID: 1, CategoryID: 10, Duration: 5 * 60},
+			2:  {ID: 2, CategoryID: 20, Duration: 60 * 60},
+			11: {ID: 11, CategoryID: 10, Duration: 5 * 60},  // 候補: ニュース
+			12: {ID: 12, CategoryID: 20, Duration: 60 * 60}, // 候補: トーク
+		},
+	}
+
+	// 平日の朝はニュース、夜はトークを聴く
+	var history []*entities.PlaybackHistory
+	for day := 1; day <= 5; day++ {
+		history = append(history,
+			&entities.PlaybackHistory{UserID: 1, AudioContentID: 1, PlayedAt: time.Date(2024, 4, day, 8, 0, 0, 0, time.UTC), Completed: true},
+			&entities.PlaybackHistory{UserID: 1, AudioContentID: 2, PlayedAt: time.Date(2024, 4, day, 23, 0, 0, 0, time.UTC), Completed: true},
+		)
+	}
+	playbackRepo := &mockPlaybackRepository{history: map[int][]*entities.PlaybackHistory{1: history}}
+	service := NewContextualBoostService(playbackRepo, contentRepo, DefaultContextualBoostConfig())
+
+	candidates := func() *entities.RecommendationSet {
+		return &entities.RecommendationSet{
+			UserID: 1,
+			Recommendations: []*entities.Recommendation{
+				{UserID: 1, AudioContentID: 11, Score: 1.0},
+				{UserID: 1, AudioContentID: 12, Score: 1.0},
+			},
+		}
+	}
+
+	t.Run("朝はニュースを加点しトークを減点", func(t *testing.T) {
+		req := &entities.RecommendationRequest{
+			UserID:  1,
+			Limit:   10,
+			Context: &entities.ListeningContext{LocalTime: time.Date(2024, 4, 8, 7, 30, 0, 0, time.UTC)},
+		}
+		recSet := candidates()
+		if err := service.AdjustSet(context.Background(), req, recSet); err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+
+		news, talk := recSet.Recommendations[0], recSet.Recommendations[1]
+		if news.Score <= 1.0 || talk.Score >= 1.0 {
+			t.Errorf("ニュース>1.0、トーク<1.0を期待しましたが、%v、%vを取得しました", news.Score, talk.Score)
+		}
+		if news.Score > DefaultContextualBoostConfig().MaxBoost {
+			t.Errorf("倍率は上限%v以下を期待しましたが、%vを取得しました", DefaultContextualBoostConfig().MaxBoost, news.Score)
+		}
+		if len(news.Adjustments) != 1 || news.Adjustments[0].Type != entities.AdjustmentTimeContext {
+			t.Errorf("時間帯の補正の記録を期待しましたが、%v を取得しました", news.Adjustments)
+		}
+	})
+
+	t.Run("利用状況の指定がなければ補正しない", func(t *testing.T) {
+		recSet := candidates()
+		if err := service.AdjustSet(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}, recSet); err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		for _, rec := range recSet.Recommendations {
+			if rec.Score != 1.0 {
+				t.Errorf("スコア1.0を期待しましたが、%vを取得しました", rec.Score)
+			}
+		}
+	})
+
+	t.Run("再生の少ない時間枠は補正しない", func(t *testing.T) {
+		req := &entities.RecommendationRequest{
+			UserID:  1,
+			Limit:   10,
+			Context: &entities.ListeningContext{LocalTime: time.Date(2024, 4, 6, 12, 0, 0, 0, time.UTC)}, // 土曜の昼
+		}
+		recSet := candidates()
+		if err := service.AdjustSet(context.Background(), req, recSet); err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		for _, rec := range recSet.Recommendations {
+			if rec.Score != 1.0 {
+				t.Errorf("スコア1.0を期待しましたが、%vを取得しました", rec.Score)
+			}
+		}
+	})
+
+	t.Run("端末に合わせて長さを加減点", func(t *testing.T) {
+		req := &entities.RecommendationRequest{
+			UserID: 1,
+			Limit:  10,
+			Context: &entities.ListeningContext{
+				LocalTime: time.Date(2024, 4, 6, 12, 0, 0, 0, time.UTC), // 時間帯の補正がない土曜の昼
+				Device:    entities.DeviceCar,
+			},
+		}
+		recSet := candidates()
+		if err := service.AdjustSet(context.Background(), req, recSet); err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+
+		news, talk := recSet.Recommendations[0], recSet.Recommendations[1]
+		if news.Score != 1.2 || talk.Score != 0.8 {
+			t.Errorf("ニュース1.2、トーク0.8を期待しましたが、%v、%vを取得しました", news.Score, talk.Score)
+		}
+	})
+}
@@ -0,0 +1,97 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+)
+
+// 続きを聴く対象にする再生履歴の期間（日）
+const continueListeningDays = 30
+
+// ContinueListeningService 途中まで聴いたコンテンツを探すドメインサービス
+type ContinueListeningService struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	dismissals       *DismissalService
+}
+
+// NewContinueListeningService コンストラクタ
+func NewContinueListeningService(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	dismissals *DismissalService,
+) *ContinueListeningService {
+	return &ContinueListeningService{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		dismissals:       dismissals,
+	}
+}
+
+// Find 最近再生を始めて未完了のコンテンツを最近再生した順に取得
+func (s *ContinueListeningService) Find(ctx context.Context, userID int, limit int) ([]*entities.ContinueListeningItem, error) {
+	playbacks, err := s.playbackRepo.GetRecentPlaybacks(ctx, userID, continueListeningDays)
+	if err != nil {
+		return nil, err
+	}
+	if len(playbacks) == 0 {
+		return []*entities.ContinueListeningItem{}, nil
+	}
+
+	seen := make(map[int]bool)
+	var ids []int
+	for _, playback := range playbacks {
+		if !seen[playback.AudioContentID] {
+			seen[playback.AudioContentID] = true
+			ids = append(ids, playback.AudioContentID)
+		}
+	}
+
+	contents, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	dismissed, err := s.dismissals.Load(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 興味なしにしたコンテンツ・作者は除く
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		if !dismissed.Suppresses(content.ID, content) {
+			contentByID[content.ID] = content
+		}
+	}
+
+	items := entities.BuildContinueListening(playbacks, contentByID)
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+
+// Name ソース名
+func (s *ContinueListeningService) Name() string {
+	return SourceContinueListening
+}
+
+// Generate 途中まで聴いたコンテンツを進捗をスコアにした候補として返す
+func (s *ContinueListeningService) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	items, err := s.Find(ctx, req.UserID, req.Limit)
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]*entities.Recommendation, 0, len(items))
+	for _, item := range items {
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         req.UserID,
+			AudioContentID: item.Content.ID,
+			Score:          item.Progress,
+			Reason:         entities.ReasonContinueListening,
+		})
+	}
+	return recommendations, nil
+}
@@ -0,0 +1,131 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+)
+
+// 興味なしにしたカテゴリのコンテンツのスコアに掛ける係数
+const dismissedCategoryPenalty = 0.2
+
+// DismissalService 「興味なし」フィードバックを候補に反映するドメインサービス
+// コンテンツ・作者の指定は除外し、カテゴリの指定は減点する
+type DismissalService struct {
+	dismissalRepo    repositories.DismissalRepository
+	audioContentRepo repositories.AudioContentRepository
+}
+
+// NewDismissalService コンストラクタ
+func NewDismissalService(
+	dismissalRepo repositories.DismissalRepository,
+	audioContentRepo repositories.AudioContentRepository,
+) *DismissalService {
+	return &DismissalService{
+		dismissalRepo:    dismissalRepo,
+		audioContentRepo: audioContentRepo,
+	}
+}
+
+// Load ユーザーが興味なしにした対象を取得
+func (s *DismissalService) Load(ctx context.Context, userID int) (*entities.DismissalSet, error) {
+	dismissals, err := s.dismissalRepo.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewDismissalSet(dismissals), nil
+}
+
+// dismissalMemoKey 興味なしをリクエストのメモに保存するキー
+const dismissalMemoKey = "dismissals"
+
+// loadFor リクエスト内で1回だけ興味なしを取得（ソースごとの除外と統合後の減点で共有）
+func (s *DismissalService) loadFor(ctx context.Context, req *entities.RecommendationRequest) (*entities.DismissalSet, error) {
+	value, err := req.Memo.Load(dismissalMemoKey, func() (interface{}, error) {
+		return s.Load(ctx, req.UserID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return value.(*entities.DismissalSet), nil
+}
+
+// contentsByID 作者・カテゴリの判定に使うメタデータを取得
+func (s *DismissalService) contentsByID(ctx context.Context, recs []*entities.Recommendation) (map[int]*entities.AudioContent, error) {
+	contentByID := make(map[int]*entities.AudioContent)
+	if len(recs) == 0 {
+		return contentByID, nil
+	}
+
+	ids := make([]int, 0, len(recs))
+	for _, rec := range recs {
+		ids = append(ids, rec.AudioContentID)
+	}
+	contents, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+	return contentByID, nil
+}
+
+// FilterCandidates ソースの候補から興味なしのコンテンツ・作者のコンテンツを除外
+func (s *DismissalService) FilterCandidates(
+	ctx context.Context,
+	req *entities.RecommendationRequest,
+	recs []*entities.Recommendation,
+) ([]*entities.Recommendation, error) {
+	set, err := s.loadFor(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if !set.HasSuppressions() {
+		return recs, nil
+	}
+
+	// 作者の判定にはメタデータが必要
+	contentByID := make(map[int]*entities.AudioContent)
+	if !set.HasContentOnly() {
+		if contentByID, err = s.contentsByID(ctx, recs); err != nil {
+			return nil, err
+		}
+	}
+
+	kept := make([]*entities.Recommendation, 0, len(recs))
+	for _, rec := range recs {
+		if !set.Suppresses(rec.AudioContentID, contentByID[rec.AudioContentID]) {
+			kept = append(kept, rec)
+		}
+	}
+	return kept, nil
+}
+
+// AdjustSet 正規化・重み付けして統合した候補のうち、興味なしのカテゴリのコンテンツを減点
+func (s *DismissalService) AdjustSet(
+	ctx context.Context,
+	req *entities.RecommendationRequest,
+	recSet *entities.RecommendationSet,
+) error {
+	set, err := s.loadFor(ctx, req)
+	if err != nil {
+		return err
+	}
+	if !set.HasCategories() {
+		return nil
+	}
+
+	contentByID, err := s.contentsByID(ctx, recSet.Recommendations)
+	if err != nil {
+		return err
+	}
+	for _, rec := range recSet.Recommendations {
+		content := contentByID[rec.AudioContentID]
+		if set.DismissedCategory(content) {
+			rec.Score *= dismissedCategoryPenalty
+			rec.AddAdjustment(entities.AdjustmentDismissedCategory, "興味なしのカテゴリ%dのためスコア×%.1f", content.CategoryID, dismissedCategoryPenalty)
+		}
+	}
+	return nil
+}
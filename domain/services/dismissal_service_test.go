@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockDismissalRepository struct {
+	dismissals []*entities.Dismissal
+}
+
+func (m *mockDismissalRepository) Save(ctx context.Context, dismissal *entities.Dismissal) error {
+	m.dismissals = append(m.dismissals, dismissal)
+	return nil
+}
+
+func (m *mockDismissalRepository) GetByUser(ctx context.Context, userID int) ([]*entities.Dismissal, error) {
+	return m.dismissals, nil
+}
+
+func newDismissalFixture() *DismissalService {
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, CategoryID: 10, AuthorID: 100},
+			2: {ID: 2, CategoryID: 10, AuthorID: 200}, // 興味なしの作者
+			3: {ID: 3, CategoryID: 30, AuthorID: 300}, // 興味なしのカテゴリ
+			4: {ID: 4, CategoryID: 10, AuthorID: 400}, // 興味なしのコンテンツ
+			5: {ID: 5, CategoryID: 10, AuthorID: 500},
+		},
+	}
+	dismissalRepo := &mockDismissalRepository{
+		dismissals: []*entities.Dismissal{
+			{UserID: 1, TargetType: entities.DismissalTargetAuthor, TargetID: 200},
+			{UserID: 1, TargetType: entities.DismissalTargetCategory, TargetID: 30},
+			{UserID: 1, TargetType: entities.DismissalTargetContent, TargetID: 4},
+		},
+	}
+	return NewDismissalService(dismissalRepo, contentRepo)
+}
+
+func TestDismissalService_FilterCandidates(t *testing.T) {
+	service := newDismissalFixture()
+
+	recs := []*entities.Recommendation{
+		{UserID: 1, AudioContentID: 1, Score: 1.0},
+		{UserID: 1, AudioContentID: 2, Score: 1.0},
+		{UserID: 1, AudioContentID: 3, Score: 1.0},
+		{UserID: 1, AudioContentID: 4, Score: 1.0},
+	}
+
+	kept, err := service.FilterCandidates(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}, recs)
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// コンテンツ・作者は除外し、カテゴリは残す（減点は統合後）
+	if len(kept) != 2 || kept[0].AudioContentID != 1 || kept[1].AudioContentID != 3 {
+		t.Fatalf("コンテンツ1、3を期待しましたが、%v を取得しました", kept)
+	}
+	if kept[1].Score != 1.0 {
+		t.Errorf("除外の段階ではスコアが変わらないことを期待しましたが、%v を取得しました", kept[1].Score)
+	}
+}
+
+func TestDismissalService_AdjustSet(t *testing.T) {
+	service := newDismissalFixture()
+
+	recSet := &entities.RecommendationSet{
+		UserID: 1,
+		Recommendations: []*entities.Recommendation{
+			{UserID: 1, AudioContentID: 1, Score: 1.0},
+			{UserID: 1, AudioContentID: 3, Score: 1.0},
+		},
+	}
+
+	if err := service.AdjustSet(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10}, recSet); err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	if recSet.Recommendations[0].Score != 1.0 {
+		t.Errorf("コンテンツ1のスコアは変わらないことを期待しましたが、%v を取得しました", recSet.Recommendations[0].Score)
+	}
+
+	// カテゴリは除外せず減点
+	penalized := recSet.Recommendations[1]
+	if penalized.Score != dismissedCategoryPenalty {
+		t.Errorf("コンテンツ3がスコア %v に減点されることを期待しましたが、%v を取得しました", dismissedCategoryPenalty, penalized.Score)
+	}
+	if len(penalized.Adjustments) != 1 || penalized.Adjustments[0].Type != entities.AdjustmentDismissedCategory {
+		t.Errorf("減点の記録を期待しましたが、%v を取得しました", penalized.Adjustments)
+	}
+}
+
+func TestBlender_Blend_DismissedContentKeepsQuota(t *testing.T) {
+	// スコア順の上位に興味なしのコンテンツ・作者が並ぶソース
+	source := &stubSource{
+		name:         "a",
+		respectLimit: true,
+		recs: []*entities.Recommendation{
+			{UserID: 1, AudioContentID: 4, Score: 0.9},
+			{UserID: 1, AudioContentID: 2, Score: 0.8},
+			{UserID: 1, AudioContentID: 1, Score: 0.7},
+			{UserID: 1, AudioContentID: 5, Score: 0.6},
+		},
+	}
+	registry := NewSourceRegistry()
+	if err := registry.Register(source); err != nil {
+		t.Fatalf("登録に失敗しました: %v", err)
+	}
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources:       []SourceConfig{{Name: "a", Weight: 1.0, Quota: 1.0}},
+		Normalization: NormalizeMinMax,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	service := newDismissalFixture()
+	blender.AddFilter(service)
+	blender.AddAdjuster(service)
+
+	recSet, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 2})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 除外した2件の分を取り直して要求どおり2件にする
+	if len(recSet.Recommendations) != 2 {
+		t.Fatalf("2件を期待しましたが、%d件を取得しました", len(recSet.Recommendations))
+	}
+	for _, rec := range recSet.Recommendations {
+		if rec.AudioContentID != 1 && rec.AudioContentID != 5 {
+			t.Errorf("コンテンツ1、5を期待しましたが、%d を取得しました", rec.AudioContentID)
+		}
+	}
+	if source.lastLimit != 4 {
+		t.Errorf("除外した分を足した4件の要求を期待しましたが、%d件を取得しました", source.lastLimit)
+	}
+}
+
+func TestBlender_Blend_DismissedCategoryOnlySource(t *testing.T) {
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, CategoryID: 10, AuthorID: 100},
+			3: {ID: 3, CategoryID: 30, AuthorID: 300}, // 興味なしのカテゴリ
+		},
+	}
+	dismissalRepo := &mockDismissalRepository{
+		dismissals: []*entities.Dismissal{
+			{UserID: 1, TargetType: entities.DismissalTargetCategory, TargetID: 30},
+		},
+	}
+
+	// ソースbは興味なしのカテゴリの候補しか返さない（正規化すると1.0になる）
+	registry := NewSourceRegistry()
+	for _, source := range []RecommendationSource{
+		&stubSource{name: "a", recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 1, Score: 0.5}}},
+		&stubSource{name: "b", recs: []*entities.Recommendation{{UserID: 1, AudioContentID: 3, Score: 0.5}}},
+	} {
+		if err := registry.Register(source); err != nil {
+			t.Fatalf("登録に失敗しました: %v", err)
+		}
+	}
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources: []SourceConfig{
+			{Name: "a", Weight: 0.5, Quota: 1.0},
+			{Name: "b", Weight: 0.5, Quota: 1.0},
+		},
+		Normalization: NormalizeMinMax,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	blender.AddAdjuster(NewDismissalService(dismissalRepo, contentRepo))
+
+	recSet, err := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	scores := make(map[int]float64)
+	for _, rec := range recSet.Recommendations {
+		scores[rec.AudioContentID] = rec.Score
+	}
+	if expected := 0.5 * dismissedCategoryPenalty; math.Abs(scores[3]-expected) > 1e-9 {
+		t.Errorf("コンテンツ3のスコア %v を期待しましたが、%v を取得しました", expected, scores[3])
+	}
+	if scores[3] >= scores[1] {
+		t.Errorf("減点したコンテンツ3がコンテンツ1（%v）より下になることを期待しましたが、%v を取得しました", scores[1], scores[3])
+	}
+}
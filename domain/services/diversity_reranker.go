@@ -0,0 +1,165 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+)
+
+// DiversityConfig 多様性リランキングの設定
+type DiversityConfig struct {
+	Lambda              float64 // 関連度の重み（1.0でスコア順そのまま、小さいほど多様性重視）
+	CategoryWeight      float64 // 同カテゴリの類似度
+	AuthorWeight        float64 // 同作者の類似度
+	AvoidAdjacentAuthor bool    // 同じ作者を隣接させない
+}
+
+// DefaultDiversityConfig デフォルト設定
+func DefaultDiversityConfig() DiversityConfig {
+	return DiversityConfig{
+		Lambda:              0.7,
+		CategoryWeight:      0.5,
+		AuthorWeight:        0.5,
+		AvoidAdjacentAuthor: true,
+	}
+}
+
+// DiversityReranker カテゴリ・作者の多様性を考慮して並べ替えるドメインサービス
+type DiversityReranker struct {
+	audioContentRepo repositories.AudioContentRepository
+	config           DiversityConfig
+}
+
+// NewDiversityReranker コンストラクタ
+func NewDiversityReranker(audioContentRepo repositories.AudioContentRepository, config DiversityConfig) *DiversityReranker {
+	return &DiversityReranker{
+		audioContentRepo: audioContentRepo,
+		config:           config,
+	}
+}
+
+// Rerank スコア順の候補をMMRで並べ替えて上位limit件を返す
+func (r *DiversityReranker) Rerank(ctx context.Context, recs []*entities.Recommendation, limit int) ([]*entities.Recommendation, error) {
+	if len(recs) == 0 {
+		return recs, nil
+	}
+
+	ids := make([]int, len(recs))
+	for i, rec := range recs {
+		ids[i] = rec.AudioContentID
+	}
+
+	contents, err := r.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	reranked := RerankMMR(recs, contentByID, r.config, limit)
+
+	originalRank := make(map[int]int, len(recs))
+	for i, rec := range recs {
+		originalRank[rec.AudioContentID] = i + 1
+	}
+	for i, rec := range reranked {
+		if from := originalRank[rec.AudioContentID]; from != i+1 {
+			rec.AddAdjustment(entities.AdjustmentDiversityRerank, "スコア順%d位から%d位に移動", from, i+1)
+		}
+	}
+
+	return reranked, nil
+}
+
+// RerankMMR 最大周辺関連性（MMR）による貪欲な並べ替え
+// 各ステップで λ・関連度 − (1−λ)・選択済みとの最大類似度 が最大の候補を選ぶ
+func RerankMMR(
+	recs []*entities.Recommendation,
+	contents map[int]*entities.AudioContent,
+	config DiversityConfig,
+	limit int,
+) []*entities.Recommendation {
+	if limit > len(recs) {
+		limit = len(recs)
+	}
+
+	maxScore := 0.0
+	for _, rec := range recs {
+		if rec.Score > maxScore {
+			maxScore = rec.Score
+		}
+	}
+
+	remaining := make([]*entities.Recommendation, len(recs))
+	copy(remaining, recs)
+	selected := make([]*entities.Recommendation, 0, limit)
+
+	for len(selected) < limit && len(remaining) > 0 {
+		best := -1
+		bestValue := 0.0
+		fallback := -1
+		fallbackValue := 0.0
+
+		for i, candidate := range remaining {
+			relevance := 0.0
+			if maxScore > 0 {
+				relevance = candidate.Score / maxScore
+			}
+
+			maxSimilarity := 0.0
+			for _, s := range selected {
+				if sim := contentSimilarity(contents[candidate.AudioContentID], contents[s.AudioContentID], config); sim > maxSimilarity {
+					maxSimilarity = sim
+				}
+			}
+
+			value := config.Lambda*relevance - (1-config.Lambda)*maxSimilarity
+
+			// 直前と同じ作者は他に候補がない場合のみ
+			if config.AvoidAdjacentAuthor && len(selected) > 0 &&
+				sameAuthor(contents[candidate.AudioContentID], contents[selected[len(selected)-1].AudioContentID]) {
+				if fallback < 0 || value > fallbackValue {
+					fallback, fallbackValue = i, value
+				}
+				continue
+			}
+
+			if best < 0 || value > bestValue {
+				best, bestValue = i, value
+			}
+		}
+
+		if best < 0 {
+			best = fallback
+		}
+
+		selected = append(selected, remaining[best])
+		remaining = append(remaining[:best], remaining[best+1:]...)
+	}
+
+	return selected
+}
+
+// contentSimilarity カテゴリ・作者による類似度（メタデータがなければ0）
+func contentSimilarity(a, b *entities.AudioContent, config DiversityConfig) float64 {
+	if a == nil || b == nil {
+		return 0
+	}
+
+	similarity := 0.0
+	if a.CategoryID == b.CategoryID {
+		similarity += config.CategoryWeight
+	}
+	if a.AuthorID == b.AuthorID {
+		similarity += config.AuthorWeight
+	}
+	return similarity
+}
+
+// sameAuthor 同じ作者かどうか
+func sameAuthor(a, b *entities.AudioContent) bool {
+	return a != nil && b != nil && a.AuthorID == b.AuthorID
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+func TestRerankMMR(t *testing.T) {
+	contents := map[int]*entities.AudioContent{
+		1: {ID: 1, CategoryID: 10, AuthorID: 100},
+		2: {ID: 2, CategoryID: 10, AuthorID: 100},
+		3: {ID: 3, CategoryID: 10, AuthorID: 101},
+		4: {ID: 4, CategoryID: 20, AuthorID: 200},
+	}
+	recs := []*entities.Recommendation{
+		{UserID: 1, AudioContentID: 1, Score: 1.0},
+		{UserID: 1, AudioContentID: 2, Score: 0.95},
+		{UserID: 1, AudioContentID: 3, Score: 0.9},
+		{UserID: 1, AudioContentID: 4, Score: 0.6},
+	}
+
+	tests := []struct {
+		name     string
+		config   DiversityConfig
+		expected []int
+	}{
+		{
+			name:     "関連度のみ（λ=1）でも同作者の隣接は避ける",
+			config:   DiversityConfig{Lambda: 1.0, CategoryWeight: 0.5, AuthorWeight: 0.5, AvoidAdjacentAuthor: true},
+			expected: []int{1, 3, 2, 4},
+		},
+		{
+			name:     "関連度のみ（λ=1）・隣接ルールなし",
+			config:   DiversityConfig{Lambda: 1.0, CategoryWeight: 0.5, AuthorWeight: 0.5},
+			expected: []int{1, 2, 3, 4},
+		},
+		{
+			name:     "デフォルト設定",
+			config:   DefaultDiversityConfig(),
+			expected: []int{1, 3, 4, 2},
+		},
+		{
+			name:     "多様性重視（λ=0.5）",
+			config:   DiversityConfig{Lambda: 0.5, CategoryWeight: 0.5, AuthorWeight: 0.5, AvoidAdjacentAuthor: true},
+			expected: []int{1, 4, 3, 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reranked := RerankMMR(recs, contents, tt.config, 4)
+
+			if len(reranked) != len(tt.expected) {
+				t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(tt.expected), len(reranked))
+			}
+			for i, rec := range reranked {
+				if rec.AudioContentID != tt.expected[i] {
+					t.Errorf("インデックス %d: コンテンツ %d を期待しましたが、%d を取得しました", i, tt.expected[i], rec.AudioContentID)
+				}
+			}
+		})
+	}
+}
+
+func TestRerankMMR_AdjacentAuthorFallback(t *testing.T) {
+	// 同じ作者しか残っていなければ隣接を許容する
+	contents := map[int]*entities.AudioContent{
+		1: {ID: 1, CategoryID: 10, AuthorID: 100},
+		2: {ID: 2, CategoryID: 10, AuthorID: 100},
+	}
+	recs := []*entities.Recommendation{
+		{UserID: 1, AudioContentID: 1, Score: 1.0},
+		{UserID: 1, AudioContentID: 2, Score: 0.5},
+	}
+
+	reranked := RerankMMR(recs, contents, DefaultDiversityConfig(), 5)
+	if len(reranked) != 2 {
+		t.Errorf("2件を期待しましたが、%d件を取得しました", len(reranked))
+	}
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sort"
+	"time"
+)
+
+const (
+	// よく聴くカテゴリとして扱う好み度上位の件数（オンボーディングで選択したカテゴリとは別）
+	shelfTopCategories = 3
+
+	// よく聴く作者として扱う件数
+	shelfTopAuthors = 5
+
+	// よく聴く作者を探す再生履歴の期間（日）
+	favoriteAuthorDays = 90
+
+	// 作者の新着として扱う期間（日）
+	authorNewContentDays = 30
+
+	// 急上昇からカテゴリで絞り込む前に取得する件数
+	shelfTrendingPool = 200
+)
+
+// ShelfProvider ホーム画面の棚を作るドメインサービス（1つの提供元が複数の棚を返してもよい）
+type ShelfProvider interface {
+	Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error)
+}
+
+// ContinueListeningShelf 「続きを聴く」の棚
+type ContinueListeningShelf struct {
+	continueListening *ContinueListeningService
+}
+
+// NewContinueListeningShelf コンストラクタ
+func NewContinueListeningShelf(continueListening *ContinueListeningService) *ContinueListeningShelf {
+	return &ContinueListeningShelf{continueListening: continueListening}
+}
+
+// Shelves 途中まで聴いたコンテンツを最近再生した順に並べた棚
+func (p *ContinueListeningShelf) Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error) {
+	recs, err := p.continueListening.Generate(ctx, &entities.RecommendationRequest{UserID: userID, Limit: limit})
+	if err != nil {
+		return nil, err
+	}
+	return []*entities.Shelf{{
+		ID:          string(entities.ShelfContinueListening),
+		Type:        entities.ShelfContinueListening,
+		Title:       "続きを聴く",
+		Explanation: "途中まで聴いたコンテンツ",
+		Items:       recs,
+	}}, nil
+}
+
+// TrendingInCategoriesShelf 「あなたのカテゴリで急上昇」の棚
+type TrendingInCategoriesShelf struct {
+	trending     *TrendingService
+	userRepo     repositories.UserRepository
+	userPrefRepo repositories.UserPreferenceRepository
+	playbackRepo repositories.PlaybackRepository
+	decay        entities.DecayConfig
+}
+
+// NewTrendingInCategoriesShelf コンストラクタ
+func NewTrendingInCategoriesShelf(
+	trending *TrendingService,
+	userRepo repositories.UserRepository,
+	userPrefRepo repositories.UserPreferenceRepository,
+	playbackRepo repositories.PlaybackRepository,
+	decay entities.DecayConfig,
+) *TrendingInCategoriesShelf {
+	return &TrendingInCategoriesShelf{
+		trending:     trending,
+		userRepo:     userRepo,
+		userPrefRepo: userPrefRepo,
+		playbackRepo: playbackRepo,
+		decay:        decay,
+	}
+}
+
+// Shelves オンボーディングで選択したカテゴリと好み度上位のカテゴリで急上昇している未再生コンテンツの棚
+func (p *TrendingInCategoriesShelf) Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error) {
+	categoryIDs, err := p.userCategories(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(categoryIDs) == 0 {
+		return []*entities.Shelf{}, nil
+	}
+	inCategories := make(map[int]bool, len(categoryIDs))
+	for _, id := range categoryIDs {
+		inCategories[id] = true
+	}
+
+	watched, err := watchedContent(ctx, p.playbackRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := p.trending.Find(ctx, shelfTrendingPool)
+	if err != nil {
+		return nil, err
+	}
+
+	var recs []*entities.Recommendation
+	for _, item := range items {
+		if !inCategories[item.Content.CategoryID] || watched[item.Content.ID] {
+			continue
+		}
+		recs = append(recs, &entities.Recommendation{
+			UserID:         userID,
+			AudioContentID: item.Content.ID,
+			Score:          item.Score,
+			Reason:         entities.ReasonTrending,
+		})
+		if len(recs) >= limit {
+			break
+		}
+	}
+
+	return []*entities.Shelf{{
+		ID:          string(entities.ShelfTrendingInCategories),
+		Type:        entities.ShelfTrendingInCategories,
+		Title:       "あなたのカテゴリで急上昇",
+		Explanation: "よく聴くカテゴリで、直近24時間に再生ペースが伸びているコンテンツ",
+		CategoryIDs: categoryIDs,
+		Items:       recs,
+	}}, nil
+}
+
+// userCategories オンボーディングで選択したカテゴリと、好み度上位のカテゴリ（重複なし）
+func (p *TrendingInCategoriesShelf) userCategories(ctx context.Context, userID int) ([]int, error) {
+	user, err := p.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	preferences, err := p.userPrefRepo.GetUserPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool)
+	var categoryIDs []int
+	if user != nil {
+		for _, id := range user.PreferredCategories {
+			if !seen[id] {
+				seen[id] = true
+				categoryIDs = append(categoryIDs, id)
+			}
+		}
+	}
+
+	// コンテンツベースと同じく、しばらく聴いていないカテゴリの好みは弱める
+	for i, preference := range entities.DecayedPreferences(preferences, p.decay, time.Now()) {
+		if i >= shelfTopCategories {
+			break
+		}
+		if !seen[preference.CategoryID] {
+			seen[preference.CategoryID] = true
+			categoryIDs = append(categoryIDs, preference.CategoryID)
+		}
+	}
+	return categoryIDs, nil
+}
+
+// NewFromAuthorsShelf 「よく聴く作者の新着」の棚
+type NewFromAuthorsShelf struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	ratingRepo       repositories.UserRatingRepository
+	decay            entities.DecayConfig
+}
+
+// NewNewFromAuthorsShelf コンストラクタ
+func NewNewFromAuthorsShelf(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	ratingRepo repositories.UserRatingRepository,
+	decay entities.DecayConfig,
+) *NewFromAuthorsShelf {
+	return &NewFromAuthorsShelf{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		ratingRepo:       ratingRepo,
+		decay:            decay,
+	}
+}
+
+// Shelves いいねしたか最後まで聴いたコンテンツの多い作者の、未再生の新着コンテンツを新しい順に並べた棚
+func (p *NewFromAuthorsShelf) Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error) {
+	playbacks, err := p.playbackRepo.GetRecentPlaybacks(ctx, userID, favoriteAuthorDays)
+	if err != nil {
+		return nil, err
+	}
+	ratings, err := p.ratingRepo.GetByUser(ctx, userID, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	// いいね・完了したコンテンツ1件ごとに作者を1回数える（同じコンテンツを繰り返し聴いても1回）
+	favorite := make(map[int]bool)
+	watched := make(map[int]bool, len(playbacks))
+	for _, playback := range playbacks {
+		watched[playback.AudioContentID] = true
+		if playback.BaseEngagementScore() >= entities.FullListenScore {
+			favorite[playback.AudioContentID] = true
+		}
+	}
+	for _, rating := range ratings {
+		if rating.IsPositive() {
+			favorite[rating.AudioContentID] = true
+		}
+	}
+	if len(favorite) == 0 {
+		return []*entities.Shelf{}, nil
+	}
+
+	ids := make([]int, 0, len(favorite))
+	for id := range favorite {
+		ids = append(ids, id)
+	}
+	contents, err := p.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	authorCounts := make(map[int]int)
+	for _, content := range contents {
+		authorCounts[content.AuthorID]++
+	}
+	authorIDs := topAuthors(authorCounts, shelfTopAuthors)
+
+	newContents, err := p.audioContentRepo.GetNewContentByAuthors(ctx, authorIDs, authorNewContentDays, limit+len(watched))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var recs []*entities.Recommendation
+	for _, content := range newContents {
+		if watched[content.ID] {
+			continue
+		}
+		recs = append(recs, &entities.Recommendation{
+			UserID:         userID,
+			AudioContentID: content.ID,
+			Score:          content.Freshness(p.decay, now),
+			Reason:         entities.ReasonNewContent,
+		})
+		if len(recs) >= limit {
+			break
+		}
+	}
+
+	return []*entities.Shelf{{
+		ID:          string(entities.ShelfNewFromAuthors),
+		Type:        entities.ShelfNewFromAuthors,
+		Title:       "よく聴く作者の新着",
+		Explanation: fmt.Sprintf("いいねしたか最後まで聴いたコンテンツの多い作者%d人の、直近%d日の新着", len(authorIDs), authorNewContentDays),
+		AuthorIDs:   authorIDs,
+		Items:       recs,
+	}}, nil
+}
+
+// topAuthors 件数の多い順に上位limit人の作者
+func topAuthors(counts map[int]int, limit int) []int {
+	authorIDs := make([]int, 0, len(counts))
+	for id := range counts {
+		authorIDs = append(authorIDs, id)
+	}
+	sort.Slice(authorIDs, func(i, j int) bool {
+		if counts[authorIDs[i]] != counts[authorIDs[j]] {
+			return counts[authorIDs[i]] > counts[authorIDs[j]]
+		}
+		return authorIDs[i] < authorIDs[j]
+	})
+	if len(authorIDs) > limit {
+		authorIDs = authorIDs[:limit]
+	}
+	return authorIDs
+}
+
+// watchedContent ユーザーが再生したことのあるコンテンツ（直近の履歴100件）
+func watchedContent(ctx context.Context, playbackRepo repositories.PlaybackRepository, userID int) (map[int]bool, error) {
+	history, err := playbackRepo.GetUserHistory(ctx, userID, 100)
+	if err != nil {
+		return nil, err
+	}
+	watched := make(map[int]bool, len(history))
+	for _, h := range history {
+		watched[h.AudioContentID] = true
+	}
+	return watched, nil
+}
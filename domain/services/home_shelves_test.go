@@ -0,0 +1,82 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+type mockUserRatingRepository struct {
+	ratings []*entities.UserRating
+}
+
+func (m *mockUserRatingRepository) Save(ctx context.Context, rating *entities.UserRating) error {
+	m.ratings = append(m.ratings, rating)
+	return nil
+}
+
+func (m *mockUserRatingRepository) GetByUser(ctx context.Context, userID int, limit int) ([]*entities.UserRating, error) {
+	return m.ratings, nil
+}
+
+func TestNewFromAuthorsShelf_Shelves(t *testing.T) {
+	now := time.Now()
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, AuthorID: 100},
+			2: {ID: 2, AuthorID: 100},
+			3: {ID: 3, AuthorID: 200},
+			4: {ID: 4, AuthorID: 300}, // 早期スキップしただけの作者
+		},
+		newContent: []*entities.AudioContent{
+			{ID: 10, AuthorID: 100, CreatedAt: now.Add(-24 * time.Hour)},
+			{ID: 2, AuthorID: 100, CreatedAt: now.Add(-48 * time.Hour)}, // 再生済み
+			{ID: 11, AuthorID: 200, CreatedAt: now.Add(-72 * time.Hour)},
+			{ID: 12, AuthorID: 300, CreatedAt: now},
+		},
+	}
+	playbackRepo := &mockPlaybackRepository{
+		history: map[int][]*entities.PlaybackHistory{
+			1: {
+				{UserID: 1, AudioContentID: 1, PlayedAt: now, Completed: true},
+				{UserID: 1, AudioContentID: 2, PlayedAt: now, Completed: true},
+				{UserID: 1, AudioContentID: 4, PlayedAt: now, Duration: 5, ContentDuration: 600},
+				// 作者200のコンテンツ3を繰り返し聴いても、作者の数え方は1件のまま
+				{UserID: 1, AudioContentID: 3, PlayedAt: now, Completed: true},
+				{UserID: 1, AudioContentID: 3, PlayedAt: now, Completed: true},
+				{UserID: 1, AudioContentID: 3, PlayedAt: now, Completed: true},
+			},
+		},
+	}
+	ratingRepo := &mockUserRatingRepository{
+		ratings: []*entities.UserRating{
+			{UserID: 1, AudioContentID: 3, Rating: entities.RatingLike, CreatedAt: now},
+		},
+	}
+
+	provider := NewNewFromAuthorsShelf(contentRepo, playbackRepo, ratingRepo, entities.DefaultDecayConfig())
+	shelves, err := provider.Shelves(context.Background(), 1, 10)
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	if len(shelves) != 1 {
+		t.Fatalf("1つの棚を期待しましたが、%d個を取得しました", len(shelves))
+	}
+
+	shelf := shelves[0]
+	expectedAuthors := []int{100, 200}
+	if len(shelf.AuthorIDs) != len(expectedAuthors) || shelf.AuthorIDs[0] != 100 || shelf.AuthorIDs[1] != 200 {
+		t.Errorf("作者 %v を期待しましたが、%v を取得しました", expectedAuthors, shelf.AuthorIDs)
+	}
+
+	expectedIDs := []int{10, 11}
+	if len(shelf.Items) != len(expectedIDs) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expectedIDs), len(shelf.Items))
+	}
+	for i, rec := range shelf.Items {
+		if rec.AudioContentID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], rec.AudioContentID)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package services
+
+import (
+	"context"
+	"log"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+const (
+	// 類似度インデックス構築に使う再生履歴の期間（日）
+	itemSimilarityDays = 90
+
+	// アイテムごとに保持する近傍数
+	itemSimilarityTopK = 50
+)
+
+// ItemSimilarityService アイテム間類似度インデックスを保持・定期再構築するドメインサービス
+type ItemSimilarityService struct {
+	playbackRepo repositories.PlaybackRepository
+	decay        entities.DecayConfig
+
+	mu    sync.RWMutex
+	index *entities.ItemSimilarityIndex
+}
+
+// NewItemSimilarityService コンストラクタ
+func NewItemSimilarityService(playbackRepo repositories.PlaybackRepository, decay entities.DecayConfig) *ItemSimilarityService {
+	return &ItemSimilarityService{
+		playbackRepo: playbackRepo,
+		decay:        decay,
+	}
+}
+
+// Index 現在のインデックスを取得（未構築ならnil）
+func (s *ItemSimilarityService) Index() *entities.ItemSimilarityIndex {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.index
+}
+
+// Rebuild ListenHistoryからインデックスを再構築して差し替え
+func (s *ItemSimilarityService) Rebuild(ctx context.Context) error {
+	playbacks, err := s.playbackRepo.GetPlaybacksWithinDays(ctx, itemSimilarityDays)
+	if err != nil {
+		return err
+	}
+
+	index := entities.BuildItemSimilarityIndex(playbacks, itemSimilarityTopK, s.decay)
+
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+
+	return nil
+}
+
+// StartRebuilder 起動時と一定間隔でインデックスを再構築
+func (s *ItemSimilarityService) StartRebuilder(ctx context.Context, interval time.Duration) {
+	if err := s.Rebuild(ctx); err != nil {
+		log.Printf("アイテム類似度インデックスの構築に失敗しました: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Rebuild(ctx); err != nil {
+				log.Printf("アイテム類似度インデックスの再構築に失敗しました: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package services
+
+import (
+	"context"
+	"log"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+// MatrixFactorizationService 潜在因子モデルの学習と提供を行うドメインサービス
+type MatrixFactorizationService struct {
+	playbackRepo repositories.PlaybackRepository
+	modelRepo    repositories.FactorModelRepository
+
+	mu    sync.RWMutex
+	model *entities.FactorModel
+}
+
+// NewMatrixFactorizationService コンストラクタ
+func NewMatrixFactorizationService(
+	playbackRepo repositories.PlaybackRepository,
+	modelRepo repositories.FactorModelRepository,
+) *MatrixFactorizationService {
+	return &MatrixFactorizationService{
+		playbackRepo: playbackRepo,
+		modelRepo:    modelRepo,
+	}
+}
+
+// Train ListenHistoryからモデルを学習して保存（HTTPサーバー外のバッチから実行）
+func (s *MatrixFactorizationService) Train(ctx context.Context, days int, config ALSConfig) (*entities.FactorModel, error) {
+	playbacks, err := s.playbackRepo.GetPlaybacksWithinDays(ctx, days)
+	if err != nil {
+		return nil, err
+	}
+
+	model, err := TrainImplicitALS(playbacks, config)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.modelRepo.Save(ctx, model); err != nil {
+		return nil, err
+	}
+
+	s.setModel(model)
+	return model, nil
+}
+
+// Model 現在のモデルを取得（未ロードならnil）
+func (s *MatrixFactorizationService) Model() *entities.FactorModel {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.model
+}
+
+// Reload 最新バージョンのモデルを読み込み（更新がなければ何もしない）
+func (s *MatrixFactorizationService) Reload(ctx context.Context) error {
+	model, err := s.modelRepo.LoadLatest(ctx)
+	if err != nil {
+		return err
+	}
+	if model == nil {
+		return nil // まだ学習されていない
+	}
+
+	if current := s.Model(); current != nil && current.Version == model.Version {
+		return nil
+	}
+
+	s.setModel(model)
+	return nil
+}
+
+// StartReloader 起動時と一定間隔で最新モデルを読み込み
+func (s *MatrixFactorizationService) StartReloader(ctx context.Context, interval time.Duration) {
+	if err := s.Reload(ctx); err != nil {
+		log.Printf("潜在因子モデルの読み込みに失敗しました: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Reload(ctx); err != nil {
+				log.Printf("潜在因子モデルの再読み込みに失敗しました: %v", err)
+			}
+		}
+	}
+}
+
+func (s *MatrixFactorizationService) setModel(model *entities.FactorModel) {
+	s.mu.Lock()
+	s.model = model
+	s.mu.Unlock()
+}
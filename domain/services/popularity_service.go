@@ -0,0 +1,75 @@
+package services
+
+import (
+	"context"
+	"log"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+// 人気度の集計対象にする、直近に再生されたコンテンツの期間（日）
+const popularityDays = 7
+
+// PopularityService いいね率・完了率のベイズ平均による品質スコア順のランキングを保持・定期再構築するドメインサービス
+// 全期間の再生・いいねの集計は重いため、リクエストごとではなく定期的に計算する
+type PopularityService struct {
+	audioContentRepo repositories.AudioContentRepository
+	config           entities.BayesianPopularityConfig
+
+	mu     sync.RWMutex
+	ranked []*entities.ScoredItem
+}
+
+// NewPopularityService コンストラクタ
+func NewPopularityService(audioContentRepo repositories.AudioContentRepository, config entities.BayesianPopularityConfig) *PopularityService {
+	return &PopularityService{
+		audioContentRepo: audioContentRepo,
+		config:           config,
+	}
+}
+
+// Ranked 現在のランキングを取得（未構築なら空）
+func (s *PopularityService) Ranked() []*entities.ScoredItem {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.ranked
+}
+
+// Rebuild 直近に再生されたコンテンツの集計からランキングを再構築して差し替え
+func (s *PopularityService) Rebuild(ctx context.Context) error {
+	stats, err := s.audioContentRepo.GetEngagementStats(ctx, popularityDays)
+	if err != nil {
+		return err
+	}
+
+	ranked := entities.RankByBayesianPopularity(stats, s.config)
+
+	s.mu.Lock()
+	s.ranked = ranked
+	s.mu.Unlock()
+
+	return nil
+}
+
+// StartRebuilder 起動時と一定間隔でランキングを再構築
+func (s *PopularityService) StartRebuilder(ctx context.Context, interval time.Duration) {
+	if err := s.Rebuild(ctx); err != nil {
+		log.Printf("人気度ランキングの構築に失敗しました: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Rebuild(ctx); err != nil {
+				log.Printf("人気度ランキングの再構築に失敗しました: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,30 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+func TestPopularityService_Rebuild(t *testing.T) {
+	contentRepo := &mockAudioContentRepository{
+		stats: []*entities.ContentEngagementStats{
+			{AudioContentID: 1, Plays: 100, Likes: 5, Completions: 30},
+			{AudioContentID: 2, Plays: 100, Likes: 30, Completions: 80},
+		},
+	}
+	service := NewPopularityService(contentRepo, entities.DefaultBayesianPopularityConfig())
+
+	if ranked := service.Ranked(); len(ranked) != 0 {
+		t.Errorf("構築前は空を期待しましたが、%d件を取得しました", len(ranked))
+	}
+
+	if err := service.Rebuild(context.Background()); err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	ranked := service.Ranked()
+	if len(ranked) != 2 || ranked[0].AudioContentID != 2 {
+		t.Errorf("いいね率・完了率の高いコンテンツ2が先頭になることを期待しましたが、%v を取得しました", ranked)
+	}
+}
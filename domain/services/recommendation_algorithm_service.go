@@ -4,6 +4,7 @@ import (
 	"context"
 	"mimiru-ai/domain/entities"
 	"mimiru-ai/domain/repositories"
+	"time"
 )
 
 // RecommendationAlgorithmService レコメンドアルゴリズムのドメインサービス
@@ -12,6 +13,11 @@ type RecommendationAlgorithmService struct {
 	audioContentRepo repositories.AudioContentRepository
 	playbackRepo     repositories.PlaybackRepository
 	userPrefRepo     repositories.UserPreferenceRepository
+	ratingRepo       repositories.UserRatingRepository
+	itemSimilarity   *ItemSimilarityService
+	factorization    *MatrixFactorizationService
+	popularity       *PopularityService
+	decay            entities.DecayConfig
 }
 
 // NewRecommendationAlgorithmService コンストラクタ
@@ -20,15 +26,44 @@ func NewRecommendationAlgorithmService(
 	audioContentRepo repositories.AudioContentRepository,
 	playbackRepo repositories.PlaybackRepository,
 	userPrefRepo repositories.UserPreferenceRepository,
+	ratingRepo repositories.UserRatingRepository,
+	itemSimilarity *ItemSimilarityService,
+	factorization *MatrixFactorizationService,
+	popularity *PopularityService,
+	decay entities.DecayConfig,
 ) *RecommendationAlgorithmService {
 	return &RecommendationAlgorithmService{
 		userRepo:         userRepo,
 		audioContentRepo: audioContentRepo,
 		playbackRepo:     playbackRepo,
 		userPrefRepo:     userPrefRepo,
+		ratingRepo:       ratingRepo,
+		itemSimilarity:   itemSimilarity,
+		factorization:    factorization,
+		popularity:       popularity,
+		decay:            decay,
 	}
 }
 
+// RegisterSources 各生成メソッドをレコメンドソースとして登録
+func (s *RecommendationAlgorithmService) RegisterSources(registry *SourceRegistry) error {
+	sources := []RecommendationSource{
+		NewFuncSource(SourceCollaborative, s.GenerateCollaborativeRecommendations),
+		NewFuncSource(SourceItemBased, s.GenerateItemBasedRecommendations),
+		NewFuncSource(SourceLatentFactors, s.GenerateMatrixFactorizationRecommendations),
+		NewFuncSource(SourceContentBased, s.GenerateContentBasedRecommendations),
+		NewFuncSource(SourcePopular, s.GeneratePopularityBasedRecommendations),
+		NewFuncSource(SourceNewContent, s.GenerateNewContentRecommendations),
+	}
+
+	for _, source := range sources {
+		if err := registry.Register(source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GenerateCollaborativeRecommendations 協調フィルタリングによるレコメンド生成
 func (s *RecommendationAlgorithmService) GenerateCollaborativeRecommendations(
 	ctx context.Context,
@@ -51,45 +86,168 @@ func (s *RecommendationAlgorithmService) GenerateCollaborativeRecommendations(
 		return nil, err
 	}
 
+	userRatings, err := s.ratingRepo.GetByUser(ctx, targetUserID, 100)
+	if err != nil {
+		return nil, err
+	}
+
 	watchedContent := make(map[int]bool)
 	for _, history := range userHistory {
 		watchedContent[history.AudioContentID] = true
 	}
+	for _, rating := range userRatings {
+		watchedContent[rating.AudioContentID] = true // 評価済みも除外
+	}
 
-	// 類似ユーザーの視聴履歴を分析
+	// 類似ユーザーの視聴履歴と評価を分析（早期スキップ・よくないは減点、古いものほど弱く）
+	now := time.Now()
 	contentScores := make(map[int]float64)
+	contentUsers := make(map[int][]int)
+	addSignal := func(contentID, similarUserID int, score float64) {
+		if watchedContent[contentID] {
+			return // 既に視聴済み
+		}
+		contentScores[contentID] += score
+		contentUsers[contentID] = entities.AppendUniqueIDs(contentUsers[contentID], similarUserID)
+	}
 	for _, similarUser := range similarUsers {
 		history, err := s.playbackRepo.GetUserHistory(ctx, similarUser.ID, 20)
 		if err != nil {
 			continue
 		}
-
 		for _, playback := range history {
-			if watchedContent[playback.AudioContentID] {
-				continue // 既に視聴済み
-			}
+			addSignal(playback.AudioContentID, similarUser.ID, playback.CalculateEngagementScore(s.decay, now))
+		}
 
-			score := playback.CalculateEngagementScore()
-			contentScores[playback.AudioContentID] += score
+		ratings, err := s.ratingRepo.GetByUser(ctx, similarUser.ID, 20)
+		if err != nil {
+			continue
+		}
+		for _, rating := range ratings {
+			addSignal(rating.AudioContentID, similarUser.ID, rating.CalculateEngagementScore(s.decay, now))
 		}
 	}
 
-	// レコメンドを生成
+	// レコメンドを生成（スコアの高い順に上位limit件）
 	var recommendations []*entities.Recommendation
 	for contentID, score := range contentScores {
 		if score >= 2.0 { // 最低スコア閾値
 			recommendation := &entities.Recommendation{
 				UserID:         targetUserID,
 				AudioContentID: contentID,
-				Score:          score * 0.4, // 協調フィルタリングの重み
+				Score:          score,
 				Reason:         entities.ReasonSimilarUsers,
+				SimilarUserIDs: contentUsers[contentID],
 			}
 			recommendations = append(recommendations, recommendation)
 		}
+	}
 
-		if len(recommendations) >= limit {
-			break
+	return topRecommendations(recommendations, limit), nil
+}
+
+// GenerateItemBasedRecommendations アイテムベース協調フィルタリングによるレコメンド生成
+func (s *RecommendationAlgorithmService) GenerateItemBasedRecommendations(
+	ctx context.Context,
+	userID int,
+	limit int,
+) ([]*entities.Recommendation, error) {
+	index := s.itemSimilarity.Index()
+	if index.Size() == 0 {
+		return []*entities.Recommendation{}, nil // インデックス未構築
+	}
+
+	// 対象ユーザーの最近の再生をシードにする
+	userHistory, err := s.playbackRepo.GetUserHistory(ctx, userID, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	userRatings, err := s.ratingRepo.GetByUser(ctx, userID, 20)
+	if err != nil {
+		return nil, err
+	}
+
+	watchedContent := make(map[int]bool)
+	for _, history := range userHistory {
+		watchedContent[history.AudioContentID] = true
+	}
+	for _, rating := range userRatings {
+		watchedContent[rating.AudioContentID] = true
+	}
+
+	// 近傍アイテムを類似度 × シードのエンゲージメントで加点（スキップ・よくないのシードの近傍は減点）
+	now := time.Now()
+	contentScores := make(map[int]float64)
+	contentSeeds := make(map[int][]int)
+	addSeed := func(seedID int, engagement float64) {
+		for _, neighbor := range index.Neighbors(seedID) {
+			if watchedContent[neighbor.AudioContentID] {
+				continue
+			}
+			contentScores[neighbor.AudioContentID] += neighbor.Similarity * engagement
+			contentSeeds[neighbor.AudioContentID] = entities.AppendUniqueIDs(contentSeeds[neighbor.AudioContentID], seedID)
+		}
+	}
+	for i, history := range userHistory {
+		if i >= 20 {
+			break // 直近20件のみ
+		}
+		addSeed(history.AudioContentID, history.CalculateEngagementScore(s.decay, now))
+	}
+	for _, rating := range userRatings {
+		addSeed(rating.AudioContentID, rating.CalculateEngagementScore(s.decay, now))
+	}
+
+	var recommendations []*entities.Recommendation
+	for contentID, score := range contentScores {
+		if score <= 0 {
+			continue // スキップしたシードの近傍が優勢
+		}
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         userID,
+			AudioContentID: contentID,
+			Score:          score,
+			Reason:         entities.ReasonSimilarItems,
+			SeedContentIDs: contentSeeds[contentID],
+		})
+	}
+
+	return topRecommendations(recommendations, limit), nil
+}
+
+// GenerateMatrixFactorizationRecommendations 潜在因子モデル（ALS）の内積によるレコメンド生成
+func (s *RecommendationAlgorithmService) GenerateMatrixFactorizationRecommendations(
+	ctx context.Context,
+	userID int,
+	limit int,
+) ([]*entities.Recommendation, error) {
+	model := s.factorization.Model()
+	if !model.HasUser(userID) {
+		return []*entities.Recommendation{}, nil // モデル未学習または学習後の新規ユーザー
+	}
+
+	userHistory, err := s.playbackRepo.GetUserHistory(ctx, userID, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	watchedContent := make(map[int]bool)
+	for _, history := range userHistory {
+		watchedContent[history.AudioContentID] = true
+	}
+
+	var recommendations []*entities.Recommendation
+	for _, item := range model.RecommendForUser(userID, watchedContent, limit) {
+		if item.Score <= 0 {
+			continue
 		}
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         userID,
+			AudioContentID: item.AudioContentID,
+			Score:          item.Score,
+			Reason:         entities.ReasonLatentFactors,
+		})
 	}
 
 	return recommendations, nil
@@ -117,15 +275,30 @@ func (s *RecommendationAlgorithmService) GenerateContentBasedRecommendations(
 		return nil, err
 	}
 
+	ratings, err := s.ratingRepo.GetByUser(ctx, userID, 100)
+	if err != nil {
+		return nil, err
+	}
+
 	excludeIDs := make([]int, len(history))
 	for i, h := range history {
 		excludeIDs[i] = h.AudioContentID
 	}
+	for _, rating := range ratings {
+		excludeIDs = append(excludeIDs, rating.AudioContentID) // 評価済み（よくないを含む）は除外
+	}
 
 	var recommendations []*entities.Recommendation
+	now := time.Now()
 	
-	// 各好みカテゴリから類似コンテンツを取得
+	// しばらく聴いていないカテゴリの好みは弱める
+	preferences = entities.DecayedPreferences(preferences, s.decay, now)
+
+	// 各好みカテゴリから類似コンテンツを取得（好みの強い順なので、limit件集まれば以降のカテゴリは上位に入らない）
 	for _, preference := range preferences {
+		if len(recommendations) >= limit {
+			break
+		}
 		if !preference.IsStrong() {
 			continue // 強い好みのみ対象
 		}
@@ -135,37 +308,37 @@ func (s *RecommendationAlgorithmService) GenerateContentBasedRecommendations(
 			preference.CategoryID, 
 			0, 
 			excludeIDs, 
-			5,
+			limit,
 		)
 		if err != nil {
 			continue
 		}
 
 		for _, content := range similarContent {
-			score := preference.Score * 0.3 // コンテンツベースの重み
 			recommendation := &entities.Recommendation{
 				UserID:         userID,
 				AudioContentID: content.ID,
-				Score:          score,
+				Score:          preference.Score,
 				Reason:         entities.ReasonContentBased,
 			}
 			recommendations = append(recommendations, recommendation)
 		}
 	}
 
-	return recommendations, nil
+	return topRecommendations(recommendations, limit), nil
 }
 
 // GeneratePopularityBasedRecommendations 人気度ベースレコメンド生成
+// 再生数の多さではなく、いいね率・完了率のベイズ平均による品質スコアで並べる
 func (s *RecommendationAlgorithmService) GeneratePopularityBasedRecommendations(
 	ctx context.Context,
 	userID int,
 	limit int,
 ) ([]*entities.Recommendation, error) {
-	// 人気コンテンツを取得
-	popularContent, err := s.audioContentRepo.GetPopularContent(ctx, 7, limit+10)
-	if err != nil {
-		return nil, err
+	// 定期的に再構築した品質スコア順のランキングを使う
+	ranked := s.popularity.Ranked()
+	if len(ranked) == 0 {
+		return []*entities.Recommendation{}, nil // ランキング未構築
 	}
 
 	// 視聴済みコンテンツを除外
@@ -180,17 +353,15 @@ func (s *RecommendationAlgorithmService) GeneratePopularityBasedRecommendations(
 	}
 
 	var recommendations []*entities.Recommendation
-	for i, content := range popularContent {
-		if watchedContent[content.ID] {
+	for _, item := range ranked {
+		if watchedContent[item.AudioContentID] {
 			continue
 		}
 
-		// 順位に応じてスコア調整
-		score := float64(limit-i) * 0.2 // 人気度の重み
 		recommendation := &entities.Recommendation{
 			UserID:         userID,
-			AudioContentID: content.ID,
-			Score:          score,
+			AudioContentID: item.AudioContentID,
+			Score:          item.Score,
 			Reason:         entities.ReasonPopular,
 		}
 		recommendations = append(recommendations, recommendation)
@@ -215,16 +386,18 @@ func (s *RecommendationAlgorithmService) GenerateNewContentRecommendations(
 		return nil, err
 	}
 
+	// 公開から時間が経つほどスコアを減衰
+	now := time.Now()
 	var recommendations []*entities.Recommendation
 	for _, content := range newContent {
 		recommendation := &entities.Recommendation{
 			UserID:         userID,
 			AudioContentID: content.ID,
-			Score:          0.1, // 新着の重み
+			Score:          content.Freshness(s.decay, now),
 			Reason:         entities.ReasonNewContent,
 		}
 		recommendations = append(recommendations, recommendation)
 	}
 
 	return recommendations, nil
-}
\ No newline at end of file
+}
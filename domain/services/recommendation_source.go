@@ -0,0 +1,103 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"sort"
+)
+
+// ソース名
+const (
+	SourceCollaborative      = "collaborative"
+	SourceItemBased          = "item_based"
+	SourceLatentFactors      = "latent_factors"
+	SourceContentBased       = "content_based"
+	SourcePopular            = "popular"
+	SourceNewContent         = "new_content"
+	SourceColdStart          = "cold_start"
+	SourceContinueListening  = "continue_listening"
+	SourceTrending           = "trending"
+	SourceSession            = "session"
+	SourceBecauseYouListened = "because_you_listened"
+)
+
+// RecommendationSource レコメンド候補を生成するソース
+type RecommendationSource interface {
+	Name() string
+	Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error)
+}
+
+// GenerateFunc 既存の生成メソッドのシグネチャ
+type GenerateFunc func(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error)
+
+// funcSource 生成関数をソースとして扱うアダプタ
+type funcSource struct {
+	name     string
+	generate GenerateFunc
+}
+
+// NewFuncSource 生成関数からソースを作成
+func NewFuncSource(name string, generate GenerateFunc) RecommendationSource {
+	return &funcSource{name: name, generate: generate}
+}
+
+func (s *funcSource) Name() string {
+	return s.name
+}
+
+func (s *funcSource) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	return s.generate(ctx, req.UserID, req.Limit)
+}
+
+// topRecommendations スコアの高い順に並べてlimit件までに絞る（同点はコンテンツID順）
+// ブレンド設定のQuotaどおりの件数を各ソースが返すよう、候補をまとめて作るソースは最後にこれを通す
+func topRecommendations(recs []*entities.Recommendation, limit int) []*entities.Recommendation {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if recs[i].Score != recs[j].Score {
+			return recs[i].Score > recs[j].Score
+		}
+		return recs[i].AudioContentID < recs[j].AudioContentID
+	})
+	if len(recs) > limit {
+		recs = recs[:limit]
+	}
+	return recs
+}
+
+// SourceRegistry 名前でソースを登録・参照するレジストリ
+type SourceRegistry struct {
+	sources map[string]RecommendationSource
+}
+
+// NewSourceRegistry コンストラクタ
+func NewSourceRegistry() *SourceRegistry {
+	return &SourceRegistry{
+		sources: make(map[string]RecommendationSource),
+	}
+}
+
+// Register ソースを登録（同名の登録はエラー）
+func (r *SourceRegistry) Register(source RecommendationSource) error {
+	if _, exists := r.sources[source.Name()]; exists {
+		return fmt.Errorf("ソース %q は既に登録されています", source.Name())
+	}
+	r.sources[source.Name()] = source
+	return nil
+}
+
+// Get 名前でソースを取得
+func (r *SourceRegistry) Get(name string) (RecommendationSource, bool) {
+	source, ok := r.sources[name]
+	return source, ok
+}
+
+// Names 登録済みのソース名（昇順）
+func (r *SourceRegistry) Names() []string {
+	names := make([]string, 0, len(r.sources))
+	for name := range r.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
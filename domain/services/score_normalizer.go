@@ -0,0 +1,99 @@
+package services
+
+import (
+	"math"
+	"mimiru-ai/domain/entities"
+	"sort"
+)
+
+// NormalizationMethod ソースごとのスコア正規化方法
+type NormalizationMethod string
+
+const (
+	NormalizeNone   NormalizationMethod = "none"   // 生スコアのまま
+	NormalizeMinMax NormalizationMethod = "minmax" // 最小-最大で(0, 1]に線形変換
+	NormalizeRank   NormalizationMethod = "rank"   // 順位のみを使い(0, 1]に変換
+	NormalizeZScore NormalizationMethod = "zscore" // 標準化した値をシグモイドで(0, 1)に変換
+)
+
+// 正規化後の最低スコア（0になると無効なレコメンドとして落ちるため）
+const normalizedScoreFloor = 0.05
+
+// NormalizeScores 1ソース分の候補スコアを正規化（スライス内のスコアを書き換える）
+func NormalizeScores(recs []*entities.Recommendation, method NormalizationMethod) {
+	if len(recs) == 0 {
+		return
+	}
+
+	switch method {
+	case NormalizeMinMax:
+		normalizeMinMax(recs)
+	case NormalizeRank:
+		normalizeRank(recs)
+	case NormalizeZScore:
+		normalizeZScore(recs)
+	}
+}
+
+func normalizeMinMax(recs []*entities.Recommendation) {
+	minScore, maxScore := recs[0].Score, recs[0].Score
+	for _, rec := range recs {
+		minScore = math.Min(minScore, rec.Score)
+		maxScore = math.Max(maxScore, rec.Score)
+	}
+
+	spread := maxScore - minScore
+	for _, rec := range recs {
+		if spread == 0 {
+			rec.Score = 1.0
+			continue
+		}
+		rec.Score = normalizedScoreFloor + (1-normalizedScoreFloor)*(rec.Score-minScore)/spread
+	}
+}
+
+func normalizeRank(recs []*entities.Recommendation) {
+	ordered := make([]*entities.Recommendation, len(recs))
+	copy(ordered, recs)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Score > ordered[j].Score
+	})
+
+	raw := make([]float64, len(ordered))
+	for i, rec := range ordered {
+		raw[i] = rec.Score
+	}
+
+	// 同点は同じ順位として扱う
+	n := float64(len(ordered))
+	rank := 0
+	for i, rec := range ordered {
+		if i > 0 && raw[i] != raw[i-1] {
+			rank = i
+		}
+		rec.Score = (n - float64(rank)) / n
+	}
+}
+
+func normalizeZScore(recs []*entities.Recommendation) {
+	n := float64(len(recs))
+	mean := 0.0
+	for _, rec := range recs {
+		mean += rec.Score
+	}
+	mean /= n
+
+	variance := 0.0
+	for _, rec := range recs {
+		variance += (rec.Score - mean) * (rec.Score - mean)
+	}
+	stddev := math.Sqrt(variance / n)
+
+	for _, rec := range recs {
+		z := 0.0
+		if stddev > 0 {
+			z = (rec.Score - mean) / stddev
+		}
+		rec.Score = 1 / (1 + math.Exp(-z))
+	}
+}
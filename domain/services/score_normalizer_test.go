@@ -0,0 +1,101 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+func newRecs(scores ...float64) []*entities.Recommendation {
+	recs := make([]*entities.Recommendation, len(scores))
+	for i, score := range scores {
+		recs[i] = &entities.Recommendation{UserID: 1, AudioContentID: i + 1, Score: score}
+	}
+	return recs
+}
+
+func TestNormalizeScores(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   NormalizationMethod
+		scores   []float64
+		expected []float64
+	}{
+		{
+			name:     "最小-最大",
+			method:   NormalizeMinMax,
+			scores:   []float64{10, 30, 20},
+			expected: []float64{normalizedScoreFloor, 1.0, normalizedScoreFloor + (1-normalizedScoreFloor)*0.5},
+		},
+		{
+			name:     "最小-最大（全て同点）",
+			method:   NormalizeMinMax,
+			scores:   []float64{0.1, 0.1},
+			expected: []float64{1.0, 1.0},
+		},
+		{
+			name:     "順位（同点は同順位）",
+			method:   NormalizeRank,
+			scores:   []float64{5, 9, 5, 1},
+			expected: []float64{0.75, 1.0, 0.75, 0.25},
+		},
+		{
+			name:     "zスコア",
+			method:   NormalizeZScore,
+			scores:   []float64{1, 3},
+			expected: []float64{1 / (1 + math.Exp(1)), 1 / (1 + math.Exp(-1))},
+		},
+		{
+			name:     "正規化なし",
+			method:   NormalizeNone,
+			scores:   []float64{7, 3},
+			expected: []float64{7, 3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recs := newRecs(tt.scores...)
+			NormalizeScores(recs, tt.method)
+
+			for i, rec := range recs {
+				if math.Abs(rec.Score-tt.expected[i]) > 1e-9 {
+					t.Errorf("インデックス %d: スコア %f を期待しましたが、%f を取得しました", i, tt.expected[i], rec.Score)
+				}
+			}
+		})
+	}
+}
+
+func TestBlender_Blend_NormalizesBeforeWeighting(t *testing.T) {
+	// 生スコアのスケールが100倍違うソース
+	small := &stubSource{name: "small", recs: newRecs(0.1, 0.2)}
+	large := &stubSource{name: "large", recs: []*entities.Recommendation{
+		{UserID: 1, AudioContentID: 10, Score: 10},
+		{UserID: 1, AudioContentID: 11, Score: 20},
+	}}
+
+	registry := NewSourceRegistry()
+	registry.Register(small)
+	registry.Register(large)
+
+	blender, err := NewBlender(registry, BlendConfig{
+		Sources: []SourceConfig{
+			{Name: "small", Weight: 0.6, Quota: 1.0},
+			{Name: "large", Weight: 0.4, Quota: 1.0},
+		},
+		Normalization: NormalizeMinMax,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	recSet, _ := blender.Blend(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	recSet.SortByScore()
+
+	// 重みの大きいソースの首位が全体の首位になる
+	if top := recSet.Recommendations[0]; top.AudioContentID != 2 || math.Abs(top.Score-0.6) > 1e-9 {
+		t.Errorf("コンテンツ2（スコア0.6）が首位であることを期待しましたが、%d（%f）を取得しました", top.AudioContentID, top.Score)
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"log"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+// SessionConfig セッション内の次のコンテンツ予測の設定
+type SessionConfig struct {
+	HistoryDays   int           // 遷移の学習に使う再生履歴の日数
+	IdleGap       time.Duration // これ以上再生の間隔が空いたら別のセッションとみなす
+	TopK          int           // コンテンツごとに保持する遷移先の数
+	ContextLength int           // 予測に使うセッションの直近の件数
+	ContextDecay  float64       // 1件遡るごとに遷移確率に掛ける係数
+}
+
+// DefaultSessionConfig デフォルト設定
+func DefaultSessionConfig() SessionConfig {
+	return SessionConfig{
+		HistoryDays:   30,
+		IdleGap:       30 * time.Minute,
+		TopK:          50,
+		ContextLength: 3,
+		ContextDecay:  0.5,
+	}
+}
+
+// SessionService セッション内の再生順から「次に聴く」コンテンツを予測するドメインサービス
+// 遷移確率のインデックスを保持し、定期的に ListenHistory から再構築する
+type SessionService struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	config           SessionConfig
+
+	mu    sync.RWMutex
+	index *entities.TransitionIndex
+}
+
+// NewSessionService コンストラクタ
+func NewSessionService(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	config SessionConfig,
+) *SessionService {
+	return &SessionService{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		config:           config,
+	}
+}
+
+// Index 現在のインデックスを取得（未構築ならnil）
+func (s *SessionService) Index() *entities.TransitionIndex {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.index
+}
+
+// Rebuild ListenHistoryからインデックスを再構築して差し替え
+func (s *SessionService) Rebuild(ctx context.Context) error {
+	playbacks, err := s.playbackRepo.GetPlaybacksWithinDays(ctx, s.config.HistoryDays)
+	if err != nil {
+		return err
+	}
+
+	index := entities.BuildTransitionIndex(playbacks, s.config.IdleGap, s.config.TopK)
+
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+
+	return nil
+}
+
+// StartRebuilder 起動時と一定間隔でインデックスを再構築
+func (s *SessionService) StartRebuilder(ctx context.Context, interval time.Duration) {
+	if err := s.Rebuild(ctx); err != nil {
+		log.Printf("セッション遷移インデックスの構築に失敗しました: %v", err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Rebuild(ctx); err != nil {
+				log.Printf("セッション遷移インデックスの再構築に失敗しました: %v", err)
+			}
+		}
+	}
+}
+
+// NextItems セッションで再生したコンテンツ（古い順）の次に聴くコンテンツを予測（スコアは遷移確率の重み付き和）
+func (s *SessionService) NextItems(ctx context.Context, session []int, limit int) ([]*entities.SimilarContent, error) {
+	predicted := s.Index().PredictNext(session, s.config.ContextLength, s.config.ContextDecay)
+	if len(predicted) > limit {
+		predicted = predicted[:limit]
+	}
+	if len(predicted) == 0 {
+		return []*entities.SimilarContent{}, nil
+	}
+
+	ids := make([]int, len(predicted))
+	for i, item := range predicted {
+		ids[i] = item.AudioContentID
+	}
+	contents, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	items := make([]*entities.SimilarContent, 0, len(predicted))
+	for _, item := range predicted {
+		content, ok := contentByID[item.AudioContentID]
+		if !ok {
+			continue
+		}
+		items = append(items, &entities.SimilarContent{
+			Content: content,
+			Score:   item.Score,
+			Reasons: []entities.RecommendationReason{entities.ReasonNextInSession},
+		})
+	}
+	return items, nil
+}
+
+// Name ソース名
+func (s *SessionService) Name() string {
+	return SourceSession
+}
+
+// Generate ユーザーの現在のセッション（最後の再生から IdleGap 以内）の続きとして、未再生のコンテンツを候補として返す
+func (s *SessionService) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	history, err := s.playbackRepo.GetUserHistory(ctx, req.UserID, 100)
+	if err != nil {
+		return nil, err
+	}
+
+	session := CurrentSession(history, s.config.IdleGap, time.Now())
+	if len(session) == 0 {
+		return []*entities.Recommendation{}, nil
+	}
+
+	watched := make(map[int]bool, len(history))
+	for _, h := range history {
+		watched[h.AudioContentID] = true
+	}
+
+	var recommendations []*entities.Recommendation
+	for _, item := range s.Index().PredictNext(session, s.config.ContextLength, s.config.ContextDecay) {
+		if watched[item.AudioContentID] {
+			continue
+		}
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         req.UserID,
+			AudioContentID: item.AudioContentID,
+			Score:          item.Score,
+			Reason:         entities.ReasonNextInSession,
+			SeedContentIDs: session,
+		})
+		if len(recommendations) >= req.Limit {
+			break
+		}
+	}
+	return recommendations, nil
+}
+
+// CurrentSession 再生履歴のうち、now から遡って IdleGap 以内の間隔で続いている再生のコンテンツ（古い順、早期スキップは除く）
+func CurrentSession(history []*entities.PlaybackHistory, idleGap time.Duration, now time.Time) []int {
+	sessions := entities.SplitSessions(history, idleGap)
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	// 履歴は1ユーザー分なので最後のセッションが最新
+	latest := sessions[len(sessions)-1]
+	if now.Sub(latest[len(latest)-1].PlayedAt) >= idleGap {
+		return nil
+	}
+
+	ids := make([]int, len(latest))
+	for i, playback := range latest {
+		ids[i] = playback.AudioContentID
+	}
+	return ids
+}
@@ -0,0 +1,74 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestSessionService_Generate(t *testing.T) {
+	long := time.Now().Add(-24 * time.Hour)
+	now := time.Now()
+
+	// 他のユーザーは 1→2→3 の順によく聴く
+	history := map[int][]*entities.PlaybackHistory{}
+	for userID := 2; userID <= 4; userID++ {
+		for i, contentID := range []int{1, 2, 3} {
+			history[userID] = append(history[userID], &entities.PlaybackHistory{
+				UserID: userID, AudioContentID: contentID, PlayedAt: long.Add(time.Duration(i) * 10 * time.Minute), Completed: true,
+			})
+		}
+	}
+	playbackRepo := &mockPlaybackRepository{history: history}
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, Title: "第1話"},
+			2: {ID: 2, Title: "第2話"},
+			3: {ID: 3, Title: "第3話"},
+		},
+	}
+
+	service := NewSessionService(contentRepo, playbackRepo, DefaultSessionConfig())
+	if err := service.Rebuild(context.Background()); err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	t.Run("現在のセッションの続きを返す", func(t *testing.T) {
+		playbackRepo.history[1] = []*entities.PlaybackHistory{
+			{UserID: 1, AudioContentID: 1, PlayedAt: now.Add(-10 * time.Minute), Completed: true},
+		}
+
+		recs, err := service.Generate(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+		if err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		if len(recs) != 1 || recs[0].AudioContentID != 2 || recs[0].Reason != entities.ReasonNextInSession {
+			t.Fatalf("コンテンツ2（next_in_session）を期待しましたが、%v を取得しました", recs)
+		}
+	})
+
+	t.Run("最後の再生から間隔が空いていれば候補なし", func(t *testing.T) {
+		playbackRepo.history[1] = []*entities.PlaybackHistory{
+			{UserID: 1, AudioContentID: 1, PlayedAt: now.Add(-2 * time.Hour), Completed: true},
+		}
+
+		recs, err := service.Generate(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+		if err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		if len(recs) != 0 {
+			t.Errorf("空の結果を期待しましたが、%d件を取得しました", len(recs))
+		}
+	})
+
+	t.Run("セッションのコンテンツIDから次を返す", func(t *testing.T) {
+		items, err := service.NextItems(context.Background(), []int{1, 2}, 10)
+		if err != nil {
+			t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+		}
+		if len(items) != 1 || items[0].Content.Title != "第3話" {
+			t.Fatalf("第3話を期待しましたが、%v を取得しました", items)
+		}
+	})
+}
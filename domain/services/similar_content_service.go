@@ -0,0 +1,143 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sort"
+)
+
+const (
+	// 共聴シグナルの集計期間（日）
+	coListeningDays = 90
+
+	// 共聴と属性（カテゴリ・作者）の配分
+	coListeningWeight = 0.6
+	attributeWeight   = 0.4
+)
+
+// SimilarContentService シードコンテンツに似たコンテンツを探すドメインサービス
+type SimilarContentService struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+}
+
+// NewSimilarContentService コンストラクタ
+func NewSimilarContentService(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+) *SimilarContentService {
+	return &SimilarContentService{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+	}
+}
+
+// FindSimilar 共聴と同カテゴリ・同作者をブレンドして類似コンテンツを取得（シード自身は除外）
+func (s *SimilarContentService) FindSimilar(
+	ctx context.Context,
+	seed *entities.AudioContent,
+	excludeIDs []int,
+	limit int,
+) ([]*entities.SimilarContent, error) {
+	excluded := make(map[int]bool, len(excludeIDs)+1)
+	excluded[seed.ID] = true
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+
+	candidates := make(map[int]*entities.SimilarContent)
+
+	// 1. 共聴（このコンテンツを聴いた人が聴いたもの）
+	coListened, err := s.playbackRepo.GetCoListenedContent(ctx, seed.ID, coListeningDays, limit*3)
+	if err != nil {
+		return nil, err
+	}
+
+	maxListeners := 0
+	var coListenedIDs []int
+	for _, c := range coListened {
+		if excluded[c.AudioContentID] {
+			continue
+		}
+		if c.ListenerCount > maxListeners {
+			maxListeners = c.ListenerCount
+		}
+		coListenedIDs = append(coListenedIDs, c.AudioContentID)
+	}
+
+	if len(coListenedIDs) > 0 {
+		contents, err := s.audioContentRepo.GetByIDs(ctx, coListenedIDs)
+		if err != nil {
+			return nil, err
+		}
+		contentByID := make(map[int]*entities.AudioContent, len(contents))
+		for _, content := range contents {
+			contentByID[content.ID] = content
+		}
+
+		for _, c := range coListened {
+			content, ok := contentByID[c.AudioContentID]
+			if !ok || excluded[c.AudioContentID] {
+				continue
+			}
+			candidates[content.ID] = &entities.SimilarContent{
+				Content: content,
+				Score:   coListeningWeight * float64(c.ListenerCount) / float64(maxListeners),
+				Reasons: []entities.RecommendationReason{entities.ReasonCoListening},
+			}
+		}
+	}
+
+	// 2. 同カテゴリ・同作者
+	exclude := make([]int, 0, len(excluded))
+	for id := range excluded {
+		exclude = append(exclude, id)
+	}
+	attributeSimilar, err := s.audioContentRepo.GetSimilarContent(ctx, seed.CategoryID, seed.AuthorID, exclude, limit*2)
+	if err != nil {
+		return nil, err
+	}
+	for _, content := range attributeSimilar {
+		if _, ok := candidates[content.ID]; !ok {
+			candidates[content.ID] = &entities.SimilarContent{Content: content}
+		}
+	}
+
+	// 属性の一致度を全候補に加点
+	results := make([]*entities.SimilarContent, 0, len(candidates))
+	for _, candidate := range candidates {
+		if score := attributeSimilarity(seed, candidate.Content); score > 0 {
+			candidate.Score += attributeWeight * score
+			candidate.Reasons = append(candidate.Reasons, entities.ReasonContentBased)
+		}
+		if candidate.Score > 0 {
+			results = append(results, candidate)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Score != results[j].Score {
+			return results[i].Score > results[j].Score
+		}
+		return results[i].Content.ID < results[j].Content.ID
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results, nil
+}
+
+// attributeSimilarity カテゴリ・作者の一致度（0.0-1.0）
+func attributeSimilarity(seed, content *entities.AudioContent) float64 {
+	score := 0.0
+	if seed.CategoryID == content.CategoryID {
+		score += 0.5
+	}
+	if seed.AuthorID == content.AuthorID {
+		score += 0.5
+	}
+	return score
+}
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+// モックリポジトリ
+type mockAudioContentRepository struct {
+	contents   map[int]*entities.AudioContent
+	similar    []*entities.AudioContent
+	popular    []*entities.AudioContent
+	newContent []*entities.AudioContent
+	stats      []*entities.ContentEngagementStats
+}
+
+func (m *mockAudioContentRepository) GetByID(ctx context.Context, contentID int) (*entities.AudioContent, error) {
+	return m.contents[contentID], nil
+}
+
+func (m *mockAudioContentRepository) GetByIDs(ctx context.Context, contentIDs []int) ([]*entities.AudioContent, error) {
+	var contents []*entities.AudioContent
+	for _, id := range contentIDs {
+		if content, ok := m.contents[id]; ok {
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
+
+func (m *mockAudioContentRepository) GetSimilarContent(ctx context.Context, categoryID, authorID int, excludeIDs []int, limit int) ([]*entities.AudioContent, error) {
+	excluded := make(map[int]bool)
+	for _, id := range excludeIDs {
+		excluded[id] = true
+	}
+	var contents []*entities.AudioContent
+	for _, content := range m.similar {
+		if !excluded[content.ID] {
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
+
+func (m *mockAudioContentRepository) GetNewContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error) {
+	return []*entities.AudioContent{}, nil
+}
+
+func (m *mockAudioContentRepository) GetPopularContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error) {
+	return []*entities.AudioContent{}, nil
+}
+
+func (m *mockAudioContentRepository) GetNewContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	return m.inCategories(m.newContent, categoryIDs, limit), nil
+}
+
+func (m *mockAudioContentRepository) GetPopularContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	return m.inCategories(m.popular, categoryIDs, limit), nil
+}
+
+func (m *mockAudioContentRepository) GetNewContentByAuthors(ctx context.Context, authorIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	var contents []*entities.AudioContent
+	for _, content := range m.newContent {
+		for _, authorID := range authorIDs {
+			if content.AuthorID == authorID && len(contents) < limit {
+				contents = append(contents, content)
+				break
+			}
+		}
+	}
+	return contents, nil
+}
+
+func (m *mockAudioContentRepository) GetEngagementStats(ctx context.Context, days int) ([]*entities.ContentEngagementStats, error) {
+	return m.stats, nil
+}
+
+func (m *mockAudioContentRepository) inCategories(candidates []*entities.AudioContent, categoryIDs []int, limit int) []*entities.AudioContent {
+	var contents []*entities.AudioContent
+	for _, content := range candidates {
+		for _, categoryID := range categoryIDs {
+			if content.CategoryID == categoryID && len(contents) < limit {
+				contents = append(contents, content)
+				break
+			}
+		}
+	}
+	return contents
+}
+
+func (m *mockAudioContentRepository) Save(ctx context.Context, content *entities.AudioContent) error {
+	return nil
+}
+
+type mockPlaybackRepository struct {
+	history    map[int][]*entities.PlaybackHistory
+	coListened []*entities.CoListenedContent
+	velocities []*entities.PlayVelocity
+}
+
+func (m *mockPlaybackRepository) GetUserHistory(ctx context.Context, userID int, limit int) ([]*entities.PlaybackHistory, error) {
+	return m.history[userID], nil
+}
+
+func (m *mockPlaybackRepository) SavePlayback(ctx context.Context, history *entities.PlaybackHistory) error {
+	return nil
+}
+
+func (m *mockPlaybackRepository) SavePlaybacks(ctx context.Context, histories []*entities.PlaybackHistory) error {
+	return nil
+}
+
+func (m *mockPlaybackRepository) GetRecentPlaybacks(ctx context.Context, userID int, days int) ([]*entities.PlaybackHistory, error) {
+	return m.history[userID], nil
+}
+
+func (m *mockPlaybackRepository) GetPlaybacksWithinDays(ctx context.Context, days int) ([]*entities.PlaybackHistory, error) {
+	var all []*entities.PlaybackHistory
+	for _, history := range m.history {
+		all = append(all, history...)
+	}
+	return all, nil
+}
+
+func (m *mockPlaybackRepository) GetCoListenedContent(ctx context.Context, contentID int, days int, limit int) ([]*entities.CoListenedContent, error) {
+	return m.coListened, nil
+}
+
+func (m *mockPlaybackRepository) GetPlayVelocities(ctx context.Context, recentWindow, baselineWindow time.Duration, minRecentPlays int) ([]*entities.PlayVelocity, error) {
+	return m.velocities, nil
+}
+
+func TestSimilarContentService_FindSimilar(t *testing.T) {
+	seed := &entities.AudioContent{ID: 1, Title: "シード", CategoryID: 10, AuthorID: 100}
+
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: seed,
+			2: {ID: 2, CategoryID: 10, AuthorID: 200}, // 共聴 + 同カテゴリ
+			3: {ID: 3, CategoryID: 20, AuthorID: 300}, // 共聴のみ
+			4: {ID: 4, CategoryID: 10, AuthorID: 100}, // 同カテゴリ + 同作者
+			5: {ID: 5, CategoryID: 30, AuthorID: 500}, // 除外指定
+		},
+		similar: []*entities.AudioContent{
+			{ID: 4, CategoryID: 10, AuthorID: 100},
+		},
+	}
+	playbackRepo := &mockPlaybackRepository{
+		coListened: []*entities.CoListenedContent{
+			{AudioContentID: 2, ListenerCount: 10},
+			{AudioContentID: 3, ListenerCount: 5},
+			{AudioContentID: 5, ListenerCount: 8},
+		},
+	}
+
+	service := NewSimilarContentService(contentRepo, playbackRepo)
+
+	results, err := service.FindSimilar(context.Background(), seed, []int{5}, 10)
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	expectedIDs := []int{2, 4, 3}
+	if len(results) != len(expectedIDs) {
+		t.Fatalf("%d件の類似コンテンツを期待しましたが、%d件を取得しました", len(expectedIDs), len(results))
+	}
+	for i, result := range results {
+		if result.Content.ID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], result.Content.ID)
+		}
+		if result.Content.ID == seed.ID {
+			t.Error("シード自身が含まれています")
+		}
+	}
+
+	if !results[0].HasReason(entities.ReasonCoListening) || !results[0].HasReason(entities.ReasonContentBased) {
+		t.Errorf("共聴と属性の両方の理由を期待しましたが、%v を取得しました", results[0].Reasons)
+	}
+}
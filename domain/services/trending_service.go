@@ -0,0 +1,168 @@
+package services
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sort"
+	"time"
+)
+
+// TrendingConfig 急上昇の判定設定
+type TrendingConfig struct {
+	RecentWindow   time.Duration // 直近の再生ペースを測る期間
+	BaselineWindow time.Duration // 比較対象とする直前の期間
+	MinRecentPlays int           // 直近の再生数の下限（少数の再生で急上昇扱いしない）
+	BaselinePrior  float64       // 比較ウィンドウの再生ペースに足す平滑化（直近ウィンドウあたりの再生数）
+	MinGrowth      float64       // 急上昇とみなす倍率の下限
+}
+
+// DefaultTrendingConfig デフォルト設定（直近24時間を前の7日間と比較）
+func DefaultTrendingConfig() TrendingConfig {
+	return TrendingConfig{
+		RecentWindow:   24 * time.Hour,
+		BaselineWindow: 7 * 24 * time.Hour,
+		MinRecentPlays: 10,
+		BaselinePrior:  2.0,
+		MinGrowth:      1.5,
+	}
+}
+
+// TrendingService 再生ペースの伸びから急上昇コンテンツを探すドメインサービス
+// 常に再生されている定番ではなく、比較ウィンドウより再生ペースが上がっているものを上位にする
+type TrendingService struct {
+	audioContentRepo repositories.AudioContentRepository
+	playbackRepo     repositories.PlaybackRepository
+	config           TrendingConfig
+}
+
+// NewTrendingService コンストラクタ
+func NewTrendingService(
+	audioContentRepo repositories.AudioContentRepository,
+	playbackRepo repositories.PlaybackRepository,
+	config TrendingConfig,
+) *TrendingService {
+	return &TrendingService{
+		audioContentRepo: audioContentRepo,
+		playbackRepo:     playbackRepo,
+		config:           config,
+	}
+}
+
+// Find 急上昇コンテンツを倍率の高い順に取得
+func (s *TrendingService) Find(ctx context.Context, limit int) ([]*entities.TrendingItem, error) {
+	velocities, err := s.playbackRepo.GetPlayVelocities(ctx, s.config.RecentWindow, s.config.BaselineWindow, s.config.MinRecentPlays)
+	if err != nil {
+		return nil, err
+	}
+
+	ranked := RankTrending(velocities, s.config)
+	if len(ranked) > limit {
+		ranked = ranked[:limit]
+	}
+	if len(ranked) == 0 {
+		return []*entities.TrendingItem{}, nil
+	}
+
+	ids := make([]int, len(ranked))
+	for i, item := range ranked {
+		ids[i] = item.Content.ID
+	}
+	contents, err := s.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	items := make([]*entities.TrendingItem, 0, len(ranked))
+	for _, item := range ranked {
+		if content, ok := contentByID[item.Content.ID]; ok {
+			item.Content = content
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// Name ソース名
+func (s *TrendingService) Name() string {
+	return SourceTrending
+}
+
+// Generate 急上昇コンテンツから未再生のものを倍率をスコアにした候補として返す
+func (s *TrendingService) Generate(ctx context.Context, req *entities.RecommendationRequest) ([]*entities.Recommendation, error) {
+	history, err := s.playbackRepo.GetUserHistory(ctx, req.UserID, 100)
+	if err != nil {
+		return nil, err
+	}
+	watched := make(map[int]bool, len(history))
+	for _, h := range history {
+		watched[h.AudioContentID] = true
+	}
+
+	items, err := s.Find(ctx, req.Limit+len(watched))
+	if err != nil {
+		return nil, err
+	}
+
+	recommendations := make([]*entities.Recommendation, 0, req.Limit)
+	for _, item := range items {
+		if watched[item.Content.ID] {
+			continue
+		}
+		recommendations = append(recommendations, &entities.Recommendation{
+			UserID:         req.UserID,
+			AudioContentID: item.Content.ID,
+			Score:          item.Score,
+			Reason:         entities.ReasonTrending,
+		})
+		if len(recommendations) >= req.Limit {
+			break
+		}
+	}
+	return recommendations, nil
+}
+
+// RankTrending 再生ペースの倍率を計算し、下限を満たすものを倍率の高い順に並べる（Content はIDのみ）
+// 倍率 = 直近の再生数 / (比較ウィンドウの再生数を直近ウィンドウの長さに換算した値 + 平滑化)
+func RankTrending(velocities []*entities.PlayVelocity, config TrendingConfig) []*entities.TrendingItem {
+	if config.RecentWindow <= 0 || config.BaselineWindow <= 0 {
+		return []*entities.TrendingItem{}
+	}
+	scale := float64(config.RecentWindow) / float64(config.BaselineWindow)
+
+	var items []*entities.TrendingItem
+	for _, v := range velocities {
+		if v.RecentPlays < config.MinRecentPlays {
+			continue
+		}
+		expected := float64(v.BaselinePlays)*scale + config.BaselinePrior
+		if expected <= 0 {
+			continue
+		}
+		growth := float64(v.RecentPlays) / expected
+		if growth < config.MinGrowth {
+			continue
+		}
+		items = append(items, &entities.TrendingItem{
+			Content:       &entities.AudioContent{ID: v.AudioContentID},
+			RecentPlays:   v.RecentPlays,
+			BaselinePlays: v.BaselinePlays,
+			Score:         growth,
+		})
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Score != items[j].Score {
+			return items[i].Score > items[j].Score
+		}
+		if items[i].RecentPlays != items[j].RecentPlays {
+			return items[i].RecentPlays > items[j].RecentPlays
+		}
+		return items[i].Content.ID < items[j].Content.ID
+	})
+	return items
+}
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"math"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+func TestRankTrending(t *testing.T) {
+	config := TrendingConfig{
+		RecentWindow:   24 * time.Hour,
+		BaselineWindow: 7 * 24 * time.Hour,
+		MinRecentPlays: 10,
+		BaselinePrior:  2.0,
+		MinGrowth:      1.5,
+	}
+	velocities := []*entities.PlayVelocity{
+		{AudioContentID: 1, RecentPlays: 100, BaselinePlays: 700}, // 定番（毎日100回）
+		{AudioContentID: 2, RecentPlays: 40, BaselinePlays: 70},   // 1日10回から急増
+		{AudioContentID: 3, RecentPlays: 20, BaselinePlays: 0},    // 新しく聴かれ始めた
+		{AudioContentID: 4, RecentPlays: 5, BaselinePlays: 0},     // 再生数が少なすぎる
+	}
+
+	items := RankTrending(velocities, config)
+
+	expectedIDs := []int{3, 2}
+	if len(items) != len(expectedIDs) {
+		t.Fatalf("%d件の急上昇を期待しましたが、%d件を取得しました", len(expectedIDs), len(items))
+	}
+	for i, item := range items {
+		if item.Content.ID != expectedIDs[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expectedIDs[i], item.Content.ID)
+		}
+	}
+
+	// 40 / (70 * 1/7 + 2) = 40 / 12
+	if math.Abs(items[1].Score-40.0/12.0) > 1e-9 {
+		t.Errorf("倍率 %v を期待しましたが、%v を取得しました", 40.0/12.0, items[1].Score)
+	}
+}
+
+func TestTrendingService_Generate(t *testing.T) {
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, Title: "急上昇1"},
+			2: {ID: 2, Title: "急上昇2"},
+		},
+	}
+	playbackRepo := &mockPlaybackRepository{
+		history: map[int][]*entities.PlaybackHistory{
+			1: {{UserID: 1, AudioContentID: 1}},
+		},
+		velocities: []*entities.PlayVelocity{
+			{AudioContentID: 1, RecentPlays: 50},
+			{AudioContentID: 2, RecentPlays: 20},
+		},
+	}
+	service := NewTrendingService(contentRepo, playbackRepo, DefaultTrendingConfig())
+
+	recs, err := service.Generate(context.Background(), &entities.RecommendationRequest{UserID: 1, Limit: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	if len(recs) != 1 || recs[0].AudioContentID != 2 {
+		t.Fatalf("再生済みを除いたコンテンツ2のみを期待しましたが、%v を取得しました", recs)
+	}
+	if recs[0].Reason != entities.ReasonTrending {
+		t.Errorf("理由 %s を期待しましたが、%s を取得しました", entities.ReasonTrending, recs[0].Reason)
+	}
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"os"
 	"time"
 
@@ -39,6 +40,17 @@ func (c *Client) Set(key string, value interface{}, expiration time.Duration) er
 	return c.rdb.Set(ctx, key, data, expiration).Err()
 }
 
+func (c *Client) SetNX(key string, value interface{}, expiration time.Duration) (bool, error) {
+	ctx := context.Background()
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false, err
+	}
+
+	return c.rdb.SetNX(ctx, key, data, expiration).Result()
+}
+
 func (c *Client) Get(key string, dest interface{}) error {
 	ctx := context.Background()
 	
@@ -50,6 +62,35 @@ func (c *Client) Get(key string, dest interface{}) error {
 	return json.Unmarshal([]byte(val), dest)
 }
 
+func (c *Client) MGet(keys []string, dests []interface{}) ([]bool, error) {
+	hits := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return hits, nil
+	}
+	if len(keys) != len(dests) {
+		return nil, fmt.Errorf("キー数(%d)と格納先数(%d)が一致しません", len(keys), len(dests))
+	}
+
+	ctx := context.Background()
+	vals, err := c.rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	for i, val := range vals {
+		str, ok := val.(string)
+		if !ok {
+			continue // キャッシュミス
+		}
+		if err := json.Unmarshal([]byte(str), dests[i]); err != nil {
+			continue
+		}
+		hits[i] = true
+	}
+
+	return hits, nil
+}
+
 func (c *Client) Delete(key string) error {
 	ctx := context.Background()
 	return c.rdb.Del(ctx, key).Err()
@@ -0,0 +1,31 @@
+package database
+
+import (
+	"context"
+	"embed"
+	"fmt"
+)
+
+// migrations このサービスが作成するテーブルのDDL（ファイル名順に適用し、何度実行してもよいように書く）
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+// Migrate 起動時にこのサービスが使うテーブル・インデックスを作成
+func (c *Client) Migrate(ctx context.Context) error {
+	entries, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		ddl, err := migrations.ReadFile("migrations/" + entry.Name())
+		if err != nil {
+			return err
+		}
+		if _, err := c.Pool.Exec(ctx, string(ddl)); err != nil {
+			return fmt.Errorf("マイグレーション %s の適用に失敗しました: %w", entry.Name(), err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+-- オンボーディングで選択したカテゴリ（GetByID のサブクエリは主キーの user_id で引く）
+CREATE TABLE IF NOT EXISTS "UserPreferredCategory" (
+    user_id     INTEGER     NOT NULL,
+    category_id INTEGER     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, category_id)
+);
@@ -0,0 +1,8 @@
+-- 「興味なし」フィードバック（主キーが Save の ON CONFLICT (user_id, target_type, target_id) の一意制約を兼ねる）
+CREATE TABLE IF NOT EXISTS "UserDismissal" (
+    user_id     INTEGER     NOT NULL,
+    target_type TEXT        NOT NULL CHECK (target_type IN ('content', 'author', 'category')),
+    target_id   INTEGER     NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, target_type, target_id)
+);
@@ -0,0 +1,11 @@
+-- 明示的な評価（主キーが Save の ON CONFLICT (user_id, audio_content_id) の一意制約を兼ねる）
+CREATE TABLE IF NOT EXISTS "UserRating" (
+    user_id          INTEGER     NOT NULL,
+    audio_content_id INTEGER     NOT NULL,
+    rating           INTEGER     NOT NULL CHECK (rating IN (1, -1)),
+    created_at       TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, audio_content_id)
+);
+
+-- コンテンツごとのいいね数の集計用
+CREATE INDEX IF NOT EXISTS "UserRating_audio_content_id_idx" ON "UserRating" (audio_content_id);
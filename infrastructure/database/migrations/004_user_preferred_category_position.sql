@@ -0,0 +1,2 @@
+-- 選択順（同じ時刻に一括で保存するため created_at では順序が分からない）
+ALTER TABLE "UserPreferredCategory" ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
@@ -5,17 +5,21 @@ import (
 	"mimiru-ai/domain/entities"
 	"mimiru-ai/domain/repositories"
 	"mimiru-ai/infrastructure/database"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // AudioContentRepositoryImpl 音声コンテンツリポジトリの実装
 type AudioContentRepositoryImpl struct {
-	db *database.Client
+	db    *database.Client
+	decay entities.DecayConfig
 }
 
 // NewAudioContentRepositoryImpl コンストラクタ
-func NewAudioContentRepositoryImpl(db *database.Client) repositories.AudioContentRepository {
+func NewAudioContentRepositoryImpl(db *database.Client, decay entities.DecayConfig) repositories.AudioContentRepository {
 	return &AudioContentRepositoryImpl{
-		db: db,
+		db:    db,
+		decay: decay,
 	}
 }
 
@@ -24,18 +28,13 @@ func (r *AudioContentRepositoryImpl) GetByID(ctx context.Context, contentID int)
 	query := `
 		SELECT id, title, description, category_id, author_id, COALESCE(duration, 0), created_at,
 			   COALESCE(play_count.count, 0) as play_count,
-			   COALESCE(like_count.count, 0) as like_count
+			   ` + likeCountSQL("ac.id") + ` as like_count
 		FROM "AudioContent" ac
 		LEFT JOIN (
 			SELECT audio_content_id, COUNT(*) as count
 			FROM "ListenHistory"
 			GROUP BY audio_content_id
 		) play_count ON ac.id = play_count.audio_content_id
-		LEFT JOIN (
-			SELECT content_id, COUNT(*) as count
-			FROM "Like"
-			GROUP BY content_id
-		) like_count ON ac.id = like_count.content_id
 		WHERE ac.id = $1
 	`
 
@@ -53,6 +52,9 @@ func (r *AudioContentRepositoryImpl) GetByID(ctx context.Context, contentID int)
 	)
 
 	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, nil
+		}
 		return nil, err
 	}
 
@@ -68,18 +70,13 @@ func (r *AudioContentRepositoryImpl) GetByIDs(ctx context.Context, contentIDs []
 	query := `
 		SELECT id, title, description, category_id, author_id, COALESCE(duration, 0), created_at,
 			   COALESCE(play_count.count, 0) as play_count,
-			   COALESCE(like_count.count, 0) as like_count
+			   ` + likeCountSQL("ac.id") + ` as like_count
 		FROM "AudioContent" ac
 		LEFT JOIN (
 			SELECT audio_content_id, COUNT(*) as count
 			FROM "ListenHistory"
 			GROUP BY audio_content_id
 		) play_count ON ac.id = play_count.audio_content_id
-		LEFT JOIN (
-			SELECT content_id, COUNT(*) as count
-			FROM "Like"
-			GROUP BY content_id
-		) like_count ON ac.id = like_count.content_id
 		WHERE ac.id = ANY($1)
 	`
 
@@ -158,12 +155,12 @@ func (r *AudioContentRepositoryImpl) GetNewContent(ctx context.Context, days int
 		SELECT id, title, description, category_id, author_id, COALESCE(duration, 0), created_at,
 			   0 as play_count, 0 as like_count
 		FROM "AudioContent"
-		WHERE created_at > NOW() - INTERVAL '3 days'
+		WHERE created_at > NOW() - make_interval(days => $1)
 		ORDER BY created_at DESC
-		LIMIT $1
+		LIMIT $2
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, limit)
+	rows, err := r.db.Pool.Query(ctx, query, days, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -195,16 +192,57 @@ func (r *AudioContentRepositoryImpl) GetNewContent(ctx context.Context, days int
 func (r *AudioContentRepositoryImpl) GetPopularContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error) {
 	query := `
 		SELECT ac.id, ac.title, ac.description, ac.category_id, ac.author_id, 
-			   COALESCE(ac.duration, 0), ac.created_at, COUNT(*) as play_count, 0 as like_count
+			   COALESCE(ac.duration, 0), ac.created_at, ` + netPlayCountSQL("lh", "ac") + ` as play_count, 0 as like_count
 		FROM "ListenHistory" lh
 		JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
-		WHERE lh.created_at > NOW() - INTERVAL '7 days'
+		WHERE lh.created_at > NOW() - make_interval(days => $1)
 		GROUP BY ac.id, ac.title, ac.description, ac.category_id, ac.author_id, ac.duration, ac.created_at
-		ORDER BY play_count DESC, ac.created_at DESC
-		LIMIT $1
+		HAVING ` + netPlayCountSQL("lh", "ac") + ` > 0
+		ORDER BY ` + decayedPlayCountSQL("lh", "ac", r.decay.PlayRecencyHalfLife) + ` DESC, ac.created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, days, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*entities.AudioContent
+	for rows.Next() {
+		var content entities.AudioContent
+		if err := rows.Scan(
+			&content.ID,
+			&content.Title,
+			&content.Description,
+			&content.CategoryID,
+			&content.AuthorID,
+			&content.Duration,
+			&content.CreatedAt,
+			&content.PlayCount,
+			&content.LikeCount,
+		); err != nil {
+			return nil, err
+		}
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetNewContentInCategories 指定カテゴリの新着コンテンツを取得
+func (r *AudioContentRepositoryImpl) GetNewContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	query := `
+		SELECT id, title, description, category_id, author_id, COALESCE(duration, 0), created_at,
+			   0 as play_count, 0 as like_count
+		FROM "AudioContent"
+		WHERE category_id = ANY($1)
+		  AND created_at > NOW() - make_interval(days => $2)
+		ORDER BY created_at DESC
+		LIMIT $3
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, limit)
+	rows, err := r.db.Pool.Query(ctx, query, categoryIDs, days, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -232,6 +270,125 @@ func (r *AudioContentRepositoryImpl) GetPopularContent(ctx context.Context, days
 	return contents, rows.Err()
 }
 
+// GetNewContentByAuthors 指定作者の新着コンテンツを取得
+func (r *AudioContentRepositoryImpl) GetNewContentByAuthors(ctx context.Context, authorIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	query := `
+		SELECT id, title, description, category_id, author_id, COALESCE(duration, 0), created_at,
+			   0 as play_count, 0 as like_count
+		FROM "AudioContent"
+		WHERE author_id = ANY($1)
+		  AND created_at > NOW() - make_interval(days => $2)
+		ORDER BY created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, authorIDs, days, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*entities.AudioContent
+	for rows.Next() {
+		var content entities.AudioContent
+		if err := rows.Scan(
+			&content.ID,
+			&content.Title,
+			&content.Description,
+			&content.CategoryID,
+			&content.AuthorID,
+			&content.Duration,
+			&content.CreatedAt,
+			&content.PlayCount,
+			&content.LikeCount,
+		); err != nil {
+			return nil, err
+		}
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetPopularContentInCategories 指定カテゴリの人気コンテンツを取得
+func (r *AudioContentRepositoryImpl) GetPopularContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	query := `
+		SELECT ac.id, ac.title, ac.description, ac.category_id, ac.author_id,
+			   COALESCE(ac.duration, 0), ac.created_at, ` + netPlayCountSQL("lh", "ac") + ` as play_count, 0 as like_count
+		FROM "ListenHistory" lh
+		JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
+		WHERE ac.category_id = ANY($1)
+		  AND lh.created_at > NOW() - make_interval(days => $2)
+		GROUP BY ac.id, ac.title, ac.description, ac.category_id, ac.author_id, ac.duration, ac.created_at
+		HAVING ` + netPlayCountSQL("lh", "ac") + ` > 0
+		ORDER BY ` + decayedPlayCountSQL("lh", "ac", r.decay.PlayRecencyHalfLife) + ` DESC, ac.created_at DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, categoryIDs, days, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var contents []*entities.AudioContent
+	for rows.Next() {
+		var content entities.AudioContent
+		if err := rows.Scan(
+			&content.ID,
+			&content.Title,
+			&content.Description,
+			&content.CategoryID,
+			&content.AuthorID,
+			&content.Duration,
+			&content.CreatedAt,
+			&content.PlayCount,
+			&content.LikeCount,
+		); err != nil {
+			return nil, err
+		}
+		contents = append(contents, &content)
+	}
+
+	return contents, rows.Err()
+}
+
+// GetEngagementStats 指定期間に再生されたコンテンツの全期間の再生数・いいね数・完了数を取得
+func (r *AudioContentRepositoryImpl) GetEngagementStats(ctx context.Context, days int) ([]*entities.ContentEngagementStats, error) {
+	query := `
+		WITH recent AS (
+			SELECT DISTINCT audio_content_id
+			FROM "ListenHistory"
+			WHERE created_at > NOW() - make_interval(days => $1)
+		)
+		SELECT lh.audio_content_id,
+			   COUNT(*) as plays,
+			   ` + likeCountSQL("lh.audio_content_id") + ` as likes,
+			   COUNT(*) FILTER (WHERE (` + engagementScoreSQL("lh", "ac") + `) >= $2) as completions
+		FROM "ListenHistory" lh
+		JOIN recent ON lh.audio_content_id = recent.audio_content_id
+		JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
+		GROUP BY lh.audio_content_id
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, days, entities.FullListenScore)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var stats []*entities.ContentEngagementStats
+	for rows.Next() {
+		var s entities.ContentEngagementStats
+		if err := rows.Scan(&s.AudioContentID, &s.Plays, &s.Likes, &s.Completions); err != nil {
+			return nil, err
+		}
+		stats = append(stats, &s)
+	}
+
+	return stats, rows.Err()
+}
+
 // Save 音声コンテンツを保存
 func (r *AudioContentRepositoryImpl) Save(ctx context.Context, content *entities.AudioContent) error {
 	if !content.IsValid() {
@@ -24,11 +24,21 @@ func (r *CacheRepositoryImpl) Get(ctx context.Context, key string, dest interfac
 	return r.cache.Get(key, dest)
 }
 
+// GetMulti 複数キーを一括取得（destsはkeysと同じ順序、戻り値は各キーのヒット有無）
+func (r *CacheRepositoryImpl) GetMulti(ctx context.Context, keys []string, dests []interface{}) ([]bool, error) {
+	return r.cache.MGet(keys, dests)
+}
+
 // Set キャッシュに値を設定
 func (r *CacheRepositoryImpl) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
 	return r.cache.Set(key, value, expiration)
 }
 
+// SetIfNotExists キーが存在しない場合のみ値を設定（設定できたらtrue）
+func (r *CacheRepositoryImpl) SetIfNotExists(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	return r.cache.SetNX(key, value, expiration)
+}
+
 // Delete キャッシュから値を削除
 func (r *CacheRepositoryImpl) Delete(ctx context.Context, key string) error {
 	return r.cache.Delete(key)
@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"context"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"mimiru-ai/infrastructure/database"
+)
+
+// DismissalRepositoryImpl 「興味なし」フィードバックリポジトリの実装
+type DismissalRepositoryImpl struct {
+	db *database.Client
+}
+
+// NewDismissalRepositoryImpl コンストラクタ
+func NewDismissalRepositoryImpl(db *database.Client) repositories.DismissalRepository {
+	return &DismissalRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save フィードバックを保存（同じ対象は1件のみ）
+func (r *DismissalRepositoryImpl) Save(ctx context.Context, dismissal *entities.Dismissal) error {
+	if !dismissal.IsValid() {
+		return ErrInvalidEntity
+	}
+
+	query := `
+		INSERT INTO "UserDismissal" (user_id, target_type, target_id, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, target_type, target_id) DO NOTHING
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		dismissal.UserID,
+		string(dismissal.TargetType),
+		dismissal.TargetID,
+		dismissal.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByUser ユーザーのフィードバックを取得
+func (r *DismissalRepositoryImpl) GetByUser(ctx context.Context, userID int) ([]*entities.Dismissal, error) {
+	query := `
+		SELECT user_id, target_type, target_id, created_at
+		FROM "UserDismissal"
+		WHERE user_id = $1
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var dismissals []*entities.Dismissal
+	for rows.Next() {
+		var d entities.Dismissal
+		var targetType string
+		if err := rows.Scan(&d.UserID, &targetType, &d.TargetID, &d.CreatedAt); err != nil {
+			return nil, err
+		}
+		d.TargetType = entities.DismissalTargetType(targetType)
+		dismissals = append(dismissals, &d)
+	}
+
+	return dismissals, rows.Err()
+}
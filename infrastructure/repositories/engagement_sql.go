@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"time"
+)
+
+// engagementScoreSQL 再生1件のエンゲージメントスコアのSQL式（entities.PlaybackHistory.BaseEngagementScore と同じ計算）
+// lh は "ListenHistory"、ac は "AudioContent" のエイリアス
+func engagementScoreSQL(lh, ac string) string {
+	return fmt.Sprintf(`
+		CASE
+			WHEN %[1]s.completed THEN %[3]f
+			WHEN COALESCE(%[2]s.duration, 0) > 0 THEN
+				CASE
+					WHEN COALESCE(%[1]s.duration, 0) < %[2]s.duration * %[4]f THEN %[5]f
+					WHEN COALESCE(%[1]s.duration, 0) >= %[2]s.duration * %[6]f THEN %[3]f
+					ELSE %[3]f * COALESCE(%[1]s.duration, 0) / %[2]s.duration
+				END
+			WHEN COALESCE(%[1]s.duration, 0) < %[7]d THEN %[5]f
+			WHEN %[1]s.duration > 60 THEN 1.5
+			ELSE 1.0
+		END`,
+		lh, ac,
+		entities.FullListenScore,
+		entities.EarlySkipRatio,
+		entities.EarlySkipScore,
+		entities.FullListenRatio,
+		entities.EarlySkipSeconds,
+	)
+}
+
+// netPlayCountSQL 早期スキップを-1、それ以外を+1として数えた再生数のSQL式
+func netPlayCountSQL(lh, ac string) string {
+	return fmt.Sprintf("SUM(CASE WHEN (%s) < 0 THEN -1 ELSE 1 END)", engagementScoreSQL(lh, ac))
+}
+
+// decayedPlayCountSQL netPlayCountSQL の各再生を経過時間で減衰させた重み付き再生数のSQL式（人気順の並び替え用）
+func decayedPlayCountSQL(lh, ac string, halfLife time.Duration) string {
+	return fmt.Sprintf("SUM(CASE WHEN (%s) < 0 THEN -1 ELSE 1 END * %s)", engagementScoreSQL(lh, ac), decaySQL(lh+".created_at", halfLife))
+}
+
+// decaySQL entities.DecayFactor と同じ指数減衰の係数のSQL式（半減期が0以下なら1）
+func decaySQL(column string, halfLife time.Duration) string {
+	if halfLife <= 0 {
+		return "1"
+	}
+	return fmt.Sprintf("POWER(0.5, GREATEST(EXTRACT(EPOCH FROM (NOW() - %s)), 0) / %f)", column, halfLife.Seconds())
+}
@@ -0,0 +1,123 @@
+package repositories
+
+import (
+	"context"
+	"encoding/gob"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	factorModelPrefix    = "als-"
+	factorModelExt       = ".gob"
+	factorModelLatest    = "LATEST"
+	factorModelKeepCount = 5
+)
+
+// FactorModelRepositoryImpl 潜在因子モデルリポジトリの実装（ディスク上のバージョン付きファイル）
+type FactorModelRepositoryImpl struct {
+	dir string
+}
+
+// NewFactorModelRepositoryImpl コンストラクタ
+func NewFactorModelRepositoryImpl(dir string) repositories.FactorModelRepository {
+	return &FactorModelRepositoryImpl{
+		dir: dir,
+	}
+}
+
+// Save モデルを als-<version>.gob として保存し、LATEST を切り替え
+func (r *FactorModelRepositoryImpl) Save(ctx context.Context, model *entities.FactorModel) error {
+	if model.Version == "" {
+		return ErrInvalidEntity
+	}
+
+	if err := os.MkdirAll(r.dir, 0o755); err != nil {
+		return err
+	}
+
+	filename := factorModelPrefix + model.Version + factorModelExt
+	if err := r.writeAtomic(filename, func(f *os.File) error {
+		return gob.NewEncoder(f).Encode(model)
+	}); err != nil {
+		return err
+	}
+
+	if err := r.writeAtomic(factorModelLatest, func(f *os.File) error {
+		_, err := f.WriteString(filename)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return r.prune()
+}
+
+// LoadLatest LATEST が指すモデルを読み込み（未保存ならnil）
+func (r *FactorModelRepositoryImpl) LoadLatest(ctx context.Context) (*entities.FactorModel, error) {
+	latest, err := os.ReadFile(filepath.Join(r.dir, factorModelLatest))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	f, err := os.Open(filepath.Join(r.dir, filepath.Base(strings.TrimSpace(string(latest)))))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var model entities.FactorModel
+	if err := gob.NewDecoder(f).Decode(&model); err != nil {
+		return nil, fmt.Errorf("モデルファイルの読み込みに失敗しました: %w", err)
+	}
+	model.Reindex()
+
+	return &model, nil
+}
+
+// writeAtomic 一時ファイルに書き込んでからリネーム
+func (r *FactorModelRepositoryImpl) writeAtomic(name string, write func(f *os.File) error) error {
+	tmp, err := os.CreateTemp(r.dir, name+".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := write(tmp); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), filepath.Join(r.dir, name))
+}
+
+// prune 古いバージョンを削除（直近のみ保持）
+func (r *FactorModelRepositoryImpl) prune() error {
+	matches, err := filepath.Glob(filepath.Join(r.dir, factorModelPrefix+"*"+factorModelExt))
+	if err != nil {
+		return err
+	}
+	if len(matches) <= factorModelKeepCount {
+		return nil
+	}
+
+	// バージョンは時刻表記なので名前順 = 学習順
+	sort.Strings(matches)
+	for _, path := range matches[:len(matches)-factorModelKeepCount] {
+		if err := os.Remove(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
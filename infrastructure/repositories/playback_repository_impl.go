@@ -5,6 +5,10 @@ import (
 	"mimiru-ai/domain/entities"
 	"mimiru-ai/domain/repositories"
 	"mimiru-ai/infrastructure/database"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // PlaybackRepositoryImpl 再生履歴リポジトリの実装
@@ -23,8 +27,10 @@ func NewPlaybackRepositoryImpl(db *database.Client) repositories.PlaybackReposit
 func (r *PlaybackRepositoryImpl) GetUserHistory(ctx context.Context, userID int, limit int) ([]*entities.PlaybackHistory, error) {
 	query := `
 		SELECT lh.user_id, lh.audio_content_id, lh.created_at, 
-			   COALESCE(lh.duration, 0) as duration, lh.completed
+			   COALESCE(lh.duration, 0) as duration, lh.completed,
+			   COALESCE(ac.duration, 0) as content_duration
 		FROM "ListenHistory" lh
+		LEFT JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
 		WHERE lh.user_id = $1
 		ORDER BY lh.created_at DESC
 		LIMIT $2
@@ -46,6 +52,7 @@ func (r *PlaybackRepositoryImpl) GetUserHistory(ctx context.Context, userID int,
 			&h.PlayedAt,
 			&duration,
 			&h.Completed,
+			&h.ContentDuration,
 		); err != nil {
 			return nil, err
 		}
@@ -56,36 +63,100 @@ func (r *PlaybackRepositoryImpl) GetUserHistory(ctx context.Context, userID int,
 	return history, rows.Err()
 }
 
-// SavePlayback 再生履歴を保存
-func (r *PlaybackRepositoryImpl) SavePlayback(ctx context.Context, history *entities.PlaybackHistory) error {
-	if !history.IsValid() {
-		return ErrInvalidEntity
-	}
-
-	query := `
+// recordListenSQL 再生系イベントを1回の再生につき1行にまとめて記録する
+// 未完了で entities.ListenResumeWindow 以内に始まった同じコンテンツの行があれば再生時間・完了を更新し、なければ新しい再生として追加する
+// （$1: user_id, $2: audio_content_id, $3: 発生時刻, $4: 再生時間, $5: 完了, $6: まとめる期間の始まり）
+const recordListenSQL = `
+		WITH open_listen AS (
+			SELECT ctid
+			FROM "ListenHistory"
+			WHERE user_id = $1
+			  AND audio_content_id = $2
+			  AND completed = false
+			  AND created_at >= $6
+			  AND created_at <= $3
+			ORDER BY created_at DESC
+			LIMIT 1
+			FOR UPDATE
+		), updated AS (
+			UPDATE "ListenHistory" lh
+			SET duration = GREATEST(COALESCE(lh.duration, 0), $4),
+				completed = $5
+			FROM open_listen
+			WHERE lh.ctid = open_listen.ctid
+			RETURNING 1
+		)
 		INSERT INTO "ListenHistory" (user_id, audio_content_id, created_at, duration, completed)
-		VALUES ($1, $2, $3, $4, $5)
+		SELECT $1, $2, $3, $4, $5
+		WHERE NOT EXISTS (SELECT 1 FROM updated)
 	`
 
-	_, err := r.db.Pool.Exec(ctx, query,
+// recordListenArgs recordListenSQL の引数
+func recordListenArgs(history *entities.PlaybackHistory) []interface{} {
+	return []interface{}{
 		history.UserID,
 		history.AudioContentID,
 		history.PlayedAt,
 		history.Duration,
 		history.Completed,
-	)
+		history.PlayedAt.Add(-entities.ListenResumeWindow),
+	}
+}
 
+// SavePlayback 再生履歴を保存（一時停止・再開などの続きのイベントは同じ再生の行を更新）
+func (r *PlaybackRepositoryImpl) SavePlayback(ctx context.Context, history *entities.PlaybackHistory) error {
+	if !history.IsValid() {
+		return ErrInvalidEntity
+	}
+
+	_, err := r.db.Pool.Exec(ctx, recordListenSQL, recordListenArgs(history)...)
 	return err
 }
 
+// SavePlaybacks 再生履歴を一括保存（発生順に SavePlayback と同じくまとめ、すべて保存するかどれも保存しない）
+func (r *PlaybackRepositoryImpl) SavePlaybacks(ctx context.Context, histories []*entities.PlaybackHistory) error {
+	if len(histories) == 0 {
+		return nil
+	}
+
+	ordered := make([]*entities.PlaybackHistory, len(histories))
+	copy(ordered, histories)
+	for _, history := range ordered {
+		if !history.IsValid() {
+			return ErrInvalidEntity
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].PlayedAt.Before(ordered[j].PlayedAt)
+	})
+
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	batch := &pgx.Batch{}
+	for _, history := range ordered {
+		batch.Queue(recordListenSQL, recordListenArgs(history)...)
+	}
+	if err := tx.SendBatch(ctx, batch).Close(); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
 // GetRecentPlaybacks 最近の再生履歴を取得
 func (r *PlaybackRepositoryImpl) GetRecentPlaybacks(ctx context.Context, userID int, days int) ([]*entities.PlaybackHistory, error) {
 	query := `
 		SELECT lh.user_id, lh.audio_content_id, lh.created_at, 
-			   COALESCE(lh.duration, 0) as duration, lh.completed
+			   COALESCE(lh.duration, 0) as duration, lh.completed,
+			   COALESCE(ac.duration, 0) as content_duration
 		FROM "ListenHistory" lh
+		LEFT JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
 		WHERE lh.user_id = $1
-		  AND lh.created_at > NOW() - INTERVAL '%d days'
+		  AND lh.created_at > NOW() - make_interval(days => $2)
 		ORDER BY lh.created_at DESC
 	`
 
@@ -105,6 +176,7 @@ func (r *PlaybackRepositoryImpl) GetRecentPlaybacks(ctx context.Context, userID
 			&h.PlayedAt,
 			&duration,
 			&h.Completed,
+			&h.ContentDuration,
 		); err != nil {
 			return nil, err
 		}
@@ -113,4 +185,112 @@ func (r *PlaybackRepositoryImpl) GetRecentPlaybacks(ctx context.Context, userID
 	}
 
 	return history, rows.Err()
-}
\ No newline at end of file
+}
+
+// GetPlaybacksWithinDays 全ユーザーの直近の再生履歴を取得（モデル構築用）
+func (r *PlaybackRepositoryImpl) GetPlaybacksWithinDays(ctx context.Context, days int) ([]*entities.PlaybackHistory, error) {
+	query := `
+		SELECT lh.user_id, lh.audio_content_id, lh.created_at,
+			   COALESCE(lh.duration, 0) as duration, lh.completed,
+			   COALESCE(ac.duration, 0) as content_duration
+		FROM "ListenHistory" lh
+		LEFT JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
+		WHERE lh.created_at > NOW() - make_interval(days => $1)
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, days)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var history []*entities.PlaybackHistory
+	for rows.Next() {
+		var h entities.PlaybackHistory
+		var duration float64
+		if err := rows.Scan(
+			&h.UserID,
+			&h.AudioContentID,
+			&h.PlayedAt,
+			&duration,
+			&h.Completed,
+			&h.ContentDuration,
+		); err != nil {
+			return nil, err
+		}
+		h.Duration = int(duration)
+		history = append(history, &h)
+	}
+
+	return history, rows.Err()
+}
+
+// GetCoListenedContent 指定コンテンツを聴いたユーザーが他に聴いたコンテンツを取得
+func (r *PlaybackRepositoryImpl) GetCoListenedContent(ctx context.Context, contentID int, days int, limit int) ([]*entities.CoListenedContent, error) {
+	query := `
+		WITH listeners AS (
+			SELECT DISTINCT user_id
+			FROM "ListenHistory"
+			WHERE audio_content_id = $1
+			  AND created_at > NOW() - make_interval(days => $2)
+		)
+		SELECT lh.audio_content_id, COUNT(DISTINCT lh.user_id) as listener_count
+		FROM "ListenHistory" lh
+		JOIN listeners l ON lh.user_id = l.user_id
+		WHERE lh.audio_content_id != $1
+		  AND lh.created_at > NOW() - make_interval(days => $2)
+		GROUP BY lh.audio_content_id
+		ORDER BY listener_count DESC
+		LIMIT $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, contentID, days, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var coListened []*entities.CoListenedContent
+	for rows.Next() {
+		var c entities.CoListenedContent
+		if err := rows.Scan(&c.AudioContentID, &c.ListenerCount); err != nil {
+			return nil, err
+		}
+		coListened = append(coListened, &c)
+	}
+
+	return coListened, rows.Err()
+}
+
+// GetPlayVelocities 直近ウィンドウとその直前の比較ウィンドウの再生数をコンテンツごとに取得
+// 早期スキップは数えず、直近の再生数が minRecentPlays 未満のコンテンツは除く
+func (r *PlaybackRepositoryImpl) GetPlayVelocities(ctx context.Context, recentWindow, baselineWindow time.Duration, minRecentPlays int) ([]*entities.PlayVelocity, error) {
+	query := `
+		SELECT lh.audio_content_id,
+			   COUNT(*) FILTER (WHERE lh.created_at > NOW() - make_interval(secs => $1::float8)) as recent_plays,
+			   COUNT(*) FILTER (WHERE lh.created_at <= NOW() - make_interval(secs => $1::float8)) as baseline_plays
+		FROM "ListenHistory" lh
+		LEFT JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
+		WHERE lh.created_at > NOW() - make_interval(secs => $1::float8 + $2::float8)
+		  AND (` + engagementScoreSQL("lh", "ac") + `) >= 0
+		GROUP BY lh.audio_content_id
+		HAVING COUNT(*) FILTER (WHERE lh.created_at > NOW() - make_interval(secs => $1::float8)) >= $3
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, recentWindow.Seconds(), baselineWindow.Seconds(), minRecentPlays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var velocities []*entities.PlayVelocity
+	for rows.Next() {
+		var v entities.PlayVelocity
+		if err := rows.Scan(&v.AudioContentID, &v.RecentPlays, &v.BaselinePlays); err != nil {
+			return nil, err
+		}
+		velocities = append(velocities, &v)
+	}
+
+	return velocities, rows.Err()
+}
@@ -5,6 +5,7 @@ import (
 	"mimiru-ai/domain/entities"
 	"mimiru-ai/domain/repositories"
 	"mimiru-ai/infrastructure/database"
+	"time"
 )
 
 // UserPreferenceRepositoryImpl ユーザー好みリポジトリの実装
@@ -21,19 +22,34 @@ func NewUserPreferenceRepositoryImpl(db *database.Client) repositories.UserPrefe
 
 // GetUserPreferences ユーザーの好みを取得
 func (r *UserPreferenceRepositoryImpl) GetUserPreferences(ctx context.Context, userID int) ([]*entities.UserPreference, error) {
+	// 早期スキップ・よくないは負のエンゲージメントとしてカテゴリの好みを下げる
 	query := `
-		SELECT ac.category_id, 
-			   COUNT(*) as play_count,
-			   AVG(CASE WHEN lh.completed THEN 1.0 ELSE 0.5 END) as completion_rate
-		FROM "ListenHistory" lh
-		JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
-		WHERE lh.user_id = $1
-		  AND lh.created_at > NOW() - INTERVAL '90 days'
-		GROUP BY ac.category_id
+		WITH signals AS (
+			SELECT ac.category_id,
+				   ` + engagementScoreSQL("lh", "ac") + ` as engagement,
+				   lh.created_at
+			FROM "ListenHistory" lh
+			JOIN "AudioContent" ac ON lh.audio_content_id = ac.id
+			WHERE lh.user_id = $1
+			  AND lh.created_at > NOW() - INTERVAL '90 days'
+			UNION ALL
+			SELECT ac.category_id,
+				   CASE WHEN ratings.rating > 0 THEN $2::float8 ELSE $3::float8 END as engagement,
+				   ratings.created_at
+			FROM ` + userRatingsSQL("user_id = $1") + ` ratings
+			JOIN "AudioContent" ac ON ratings.audio_content_id = ac.id
+			WHERE ratings.created_at > NOW() - INTERVAL '90 days'
+		)
+		SELECT category_id,
+			   COUNT(*) as signal_count,
+			   SUM(engagement) as engagement,
+			   MAX(created_at) as last_signal_at
+		FROM signals
+		GROUP BY category_id
 		HAVING COUNT(*) >= 3
 	`
 
-	rows, err := r.db.Pool.Query(ctx, query, userID)
+	rows, err := r.db.Pool.Query(ctx, query, userID, entities.LikeScore, entities.DislikeScore)
 	if err != nil {
 		return nil, err
 	}
@@ -42,15 +58,21 @@ func (r *UserPreferenceRepositoryImpl) GetUserPreferences(ctx context.Context, u
 	var preferences []*entities.UserPreference
 	for rows.Next() {
 		var categoryID int
-		var playCount int
-		var completionRate float64
+		var signalCount int
+		var engagement float64
+		var lastSignalAt time.Time
 
-		if err := rows.Scan(&categoryID, &playCount, &completionRate); err != nil {
+		if err := rows.Scan(&categoryID, &signalCount, &engagement, &lastSignalAt); err != nil {
 			return nil, err
 		}
 
-		// スコア計算: 再生回数 × 完了率を正規化
-		score := (float64(playCount) * completionRate) / 100.0
+		// スキップ・よくないの多いカテゴリは好みとしない
+		if engagement <= 0 {
+			continue
+		}
+
+		// スコア計算: エンゲージメントの合計を正規化（完了1回 = 2.0）
+		score := engagement / (entities.FullListenScore * 100.0)
 		if score > 1.0 {
 			score = 1.0
 		}
@@ -59,6 +81,7 @@ func (r *UserPreferenceRepositoryImpl) GetUserPreferences(ctx context.Context, u
 			UserID:     userID,
 			CategoryID: categoryID,
 			Score:      score,
+			UpdatedAt:  lastSignalAt, // 最後に聴いた・評価した日時から好みの鮮度を減衰させる
 		}
 		preferences = append(preferences, preference)
 	}
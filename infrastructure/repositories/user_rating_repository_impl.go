@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"mimiru-ai/infrastructure/database"
+)
+
+// UserRatingRepositoryImpl 明示的な評価リポジトリの実装
+type UserRatingRepositoryImpl struct {
+	db *database.Client
+}
+
+// NewUserRatingRepositoryImpl コンストラクタ
+func NewUserRatingRepositoryImpl(db *database.Client) repositories.UserRatingRepository {
+	return &UserRatingRepositoryImpl{
+		db: db,
+	}
+}
+
+// Save 評価を保存（同じコンテンツへの評価は最新のもので上書き）
+func (r *UserRatingRepositoryImpl) Save(ctx context.Context, rating *entities.UserRating) error {
+	if !rating.IsValid() {
+		return ErrInvalidEntity
+	}
+
+	query := `
+		INSERT INTO "UserRating" (user_id, audio_content_id, rating, created_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, audio_content_id) DO UPDATE SET
+			rating = EXCLUDED.rating,
+			created_at = EXCLUDED.created_at
+	`
+
+	_, err := r.db.Pool.Exec(ctx, query,
+		rating.UserID,
+		rating.AudioContentID,
+		rating.Rating,
+		rating.CreatedAt,
+	)
+
+	return err
+}
+
+// GetByUser ユーザーの評価を新しい順に取得
+func (r *UserRatingRepositoryImpl) GetByUser(ctx context.Context, userID int, limit int) ([]*entities.UserRating, error) {
+	query := `
+		SELECT user_id, audio_content_id, rating, created_at
+		FROM ` + userRatingsSQL("user_id = $1") + ` ratings
+		ORDER BY created_at DESC
+		LIMIT $2
+	`
+
+	rows, err := r.db.Pool.Query(ctx, query, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ratings []*entities.UserRating
+	for rows.Next() {
+		var rating entities.UserRating
+		if err := rows.Scan(&rating.UserID, &rating.AudioContentID, &rating.Rating, &rating.CreatedAt); err != nil {
+			return nil, err
+		}
+		ratings = append(ratings, &rating)
+	}
+
+	return ratings, rows.Err()
+}
+
+// userRatingsSQL 評価（user_id, audio_content_id, rating, created_at）のサブクエリ
+// "UserRating" に加えて、評価のない従来の "Like" もいいねとして含める。userFilter は user_id に対する条件
+func userRatingsSQL(userFilter string) string {
+	return fmt.Sprintf(`(
+			SELECT user_id, audio_content_id, rating, created_at
+			FROM "UserRating"
+			WHERE %[1]s
+			UNION ALL
+			SELECT l.user_id, l.content_id, %[2]d, l.created_at
+			FROM "Like" l
+			WHERE l.%[1]s
+			  AND NOT EXISTS (
+				SELECT 1 FROM "UserRating" ur
+				WHERE ur.user_id = l.user_id AND ur.audio_content_id = l.content_id
+			  )
+		)`, userFilter, entities.RatingLike)
+}
+
+// likeCountSQL コンテンツ1件のいいね数のサブクエリ（contentID はコンテンツIDの列）
+// 全体を集計しないよう、"UserRating" と "Like" をそれぞれコンテンツIDで絞り込む
+func likeCountSQL(contentID string) string {
+	return fmt.Sprintf(`((
+			SELECT COUNT(*)
+			FROM "UserRating" ur
+			WHERE ur.audio_content_id = %[1]s
+			  AND ur.rating > 0
+		) + (
+			SELECT COUNT(*)
+			FROM "Like" l
+			WHERE l.content_id = %[1]s
+			  AND NOT EXISTS (
+				SELECT 1 FROM "UserRating" ur
+				WHERE ur.user_id = l.user_id AND ur.audio_content_id = l.content_id
+			  )
+		))`, contentID)
+}
@@ -24,9 +24,14 @@ func NewUserRepositoryImpl(db *database.Client) repositories.UserRepository {
 // GetByID IDでユーザーを取得
 func (r *UserRepositoryImpl) GetByID(ctx context.Context, userID int) (*entities.User, error) {
 	query := `
-		SELECT id, email, created_at 
-		FROM "User" 
-		WHERE id = $1
+		SELECT u.id, u.email, u.created_at,
+			   COALESCE((
+				   SELECT array_agg(upc.category_id ORDER BY upc.position, upc.category_id)
+				   FROM "UserPreferredCategory" upc
+				   WHERE upc.user_id = u.id
+			   ), '{}')
+		FROM "User" u
+		WHERE u.id = $1
 	`
 
 	var user entities.User
@@ -34,6 +39,7 @@ func (r *UserRepositoryImpl) GetByID(ctx context.Context, userID int) (*entities
 		&user.ID,
 		&user.Email,
 		&user.CreatedAt,
+		&user.PreferredCategories,
 	)
 
 	if err != nil {
@@ -107,4 +113,31 @@ func (r *UserRepositoryImpl) Save(ctx context.Context, user *entities.User) erro
 
 	err := r.db.Pool.QueryRow(ctx, query, user.Email, user.CreatedAt).Scan(&user.ID)
 	return err
-}
\ No newline at end of file
+}
+
+// SavePreferredCategories オンボーディングで選択したカテゴリを選択順を保って置き換えて保存
+func (r *UserRepositoryImpl) SavePreferredCategories(ctx context.Context, userID int, categoryIDs []int) error {
+	tx, err := r.db.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err := tx.Exec(ctx, `DELETE FROM "UserPreferredCategory" WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+
+	if len(categoryIDs) > 0 {
+		query := `
+			INSERT INTO "UserPreferredCategory" (user_id, category_id, position, created_at)
+			SELECT $1, selected.category_id, selected.position, NOW()
+			FROM unnest($2::int[]) WITH ORDINALITY AS selected(category_id, position)
+			ON CONFLICT (user_id, category_id) DO NOTHING
+		`
+		if _, err := tx.Exec(ctx, query, userID, categoryIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
@@ -0,0 +1,133 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+const (
+	// MinQueueMinutes キューの目標時間の下限（分）
+	MinQueueMinutes = 5
+
+	// MaxQueueMinutes キューの目標時間の上限（分）
+	MaxQueueMinutes = 240
+
+	// キューの組み合わせを探すレコメンド候補数
+	queueCandidatePool = 50
+)
+
+// BuildQueueInput 再生キュー作成の入力
+type BuildQueueInput struct {
+	UserID        int
+	TargetMinutes int
+	Context       *ListeningContextInput // 利用状況（任意）
+}
+
+// QueueItem 再生キューの項目
+type QueueItem struct {
+	AudioContentID int                           `json:"audioContentId"`
+	Title          string                        `json:"title"`
+	CategoryID     int                           `json:"categoryId"`
+	AuthorID       int                           `json:"authorId"`
+	Duration       int                           `json:"duration"`
+	Score          float64                       `json:"score"`
+	Reason         entities.RecommendationReason `json:"reason"`
+}
+
+// BuildQueueOutput 再生キュー作成の出力（Items は再生順）
+type BuildQueueOutput struct {
+	UserID         int          `json:"userId"`
+	TargetDuration int          `json:"targetDuration"`
+	TotalDuration  int          `json:"totalDuration"`
+	Items          []*QueueItem `json:"items"`
+	Timestamp      int64        `json:"timestamp"`
+}
+
+// BuildQueueUsecase 目標時間に合わせた再生キュー作成ユースケース
+type BuildQueueUsecase struct {
+	getRecommendationsUC *GetRecommendationsUsecase
+	audioContentRepo     repositories.AudioContentRepository
+	config               entities.QueueConfig
+}
+
+// NewBuildQueueUsecase コンストラクタ
+func NewBuildQueueUsecase(
+	getRecommendationsUC *GetRecommendationsUsecase,
+	audioContentRepo repositories.AudioContentRepository,
+	config entities.QueueConfig,
+) *BuildQueueUsecase {
+	return &BuildQueueUsecase{
+		getRecommendationsUC: getRecommendationsUC,
+		audioContentRepo:     audioContentRepo,
+		config:               config,
+	}
+}
+
+// Execute ユースケース実行
+func (uc *BuildQueueUsecase) Execute(ctx context.Context, input *BuildQueueInput) (*BuildQueueOutput, error) {
+	if input.TargetMinutes < MinQueueMinutes || input.TargetMinutes > MaxQueueMinutes {
+		return nil, fmt.Errorf("%w: %d分（%d〜%d分）", ErrInvalidQueueDuration, input.TargetMinutes, MinQueueMinutes, MaxQueueMinutes)
+	}
+
+	// ユーザーの検証・利用状況による補正はレコメンド取得と共通
+	// フィードと件数が異なり、キャッシュを共有すると互いの件数が変わってしまうためキャッシュは使わない
+	recs, err := uc.getRecommendationsUC.Execute(ctx, &GetRecommendationsInput{
+		UserID:    input.UserID,
+		Limit:     queueCandidatePool,
+		Context:   input.Context,
+		SkipCache: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, len(recs.Recommendations))
+	for i, rec := range recs.Recommendations {
+		ids[i] = rec.AudioContentID
+	}
+	contents, err := uc.audioContentRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("コンテンツ情報の取得に失敗しました: %w", err)
+	}
+	contentByID := make(map[int]*entities.AudioContent, len(contents))
+	for _, content := range contents {
+		contentByID[content.ID] = content
+	}
+
+	candidates := make([]*entities.QueueCandidate, 0, len(recs.Recommendations))
+	for _, rec := range recs.Recommendations {
+		if content, ok := contentByID[rec.AudioContentID]; ok {
+			candidates = append(candidates, &entities.QueueCandidate{
+				Content: content,
+				Score:   rec.Score,
+				Reason:  rec.Reason,
+			})
+		}
+	}
+
+	queue := entities.BuildListeningQueue(candidates, input.TargetMinutes*60, uc.config)
+
+	items := make([]*QueueItem, 0, len(queue.Items))
+	for _, item := range queue.Items {
+		items = append(items, &QueueItem{
+			AudioContentID: item.Content.ID,
+			Title:          item.Content.Title,
+			CategoryID:     item.Content.CategoryID,
+			AuthorID:       item.Content.AuthorID,
+			Duration:       item.Content.Duration,
+			Score:          item.Score,
+			Reason:         item.Reason,
+		})
+	}
+
+	return &BuildQueueOutput{
+		UserID:         input.UserID,
+		TargetDuration: queue.TargetDuration,
+		TotalDuration:  queue.TotalDuration,
+		Items:          items,
+		Timestamp:      time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,112 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockAudioContentRepository struct {
+	contents map[int]*entities.AudioContent
+}
+
+func (m *mockAudioContentRepository) GetByID(ctx context.Context, contentID int) (*entities.AudioContent, error) {
+	return m.contents[contentID], nil
+}
+
+func (m *mockAudioContentRepository) GetByIDs(ctx context.Context, contentIDs []int) ([]*entities.AudioContent, error) {
+	var contents []*entities.AudioContent
+	for _, id := range contentIDs {
+		if content, ok := m.contents[id]; ok {
+			contents = append(contents, content)
+		}
+	}
+	return contents, nil
+}
+
+func (m *mockAudioContentRepository) GetSimilarContent(ctx context.Context, categoryID, authorID int, excludeIDs []int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetNewContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetPopularContent(ctx context.Context, days int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetNewContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetPopularContentInCategories(ctx context.Context, categoryIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetNewContentByAuthors(ctx context.Context, authorIDs []int, days int, limit int) ([]*entities.AudioContent, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) GetEngagementStats(ctx context.Context, days int) ([]*entities.ContentEngagementStats, error) {
+	return nil, nil
+}
+
+func (m *mockAudioContentRepository) Save(ctx context.Context, content *entities.AudioContent) error {
+	return nil
+}
+
+func TestBuildQueueUsecase_Execute(t *testing.T) {
+	getRecommendationsUC := NewGetRecommendationsUsecase(
+		&mockRecommendationBlender{
+			recommendations: []*entities.Recommendation{
+				{UserID: 123, AudioContentID: 1, Score: 0.9, Reason: entities.ReasonSimilarItems},
+				{UserID: 123, AudioContentID: 2, Score: 0.8, Reason: entities.ReasonPopular},
+				{UserID: 123, AudioContentID: 3, Score: 0.7, Reason: entities.ReasonNewContent},
+			},
+		},
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		// フィード用にキャッシュされた少ない件数は候補に使わない
+		&mockCacheRepository{data: map[string]interface{}{
+			recommendationCacheKey(123): &GetRecommendationsOutput{
+				UserID:          123,
+				Recommendations: []*entities.Recommendation{{UserID: 123, AudioContentID: 2, Score: 1.0}},
+			},
+		}},
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+	contentRepo := &mockAudioContentRepository{
+		contents: map[int]*entities.AudioContent{
+			1: {ID: 1, Title: "ニュース", CategoryID: 10, AuthorID: 100, Duration: 15 * 60},
+			2: {ID: 2, Title: "トーク", CategoryID: 20, AuthorID: 200, Duration: 60 * 60},
+			3: {ID: 3, Title: "コラム", CategoryID: 30, AuthorID: 300, Duration: 14 * 60},
+		},
+	}
+
+	usecase := NewBuildQueueUsecase(getRecommendationsUC, contentRepo, entities.DefaultQueueConfig())
+	output, err := usecase.Execute(context.Background(), &BuildQueueInput{UserID: 123, TargetMinutes: 30})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 60分のトークは収まらないため、ニュースとコラムで29分
+	if len(output.Items) != 2 || output.Items[0].Title != "ニュース" || output.Items[1].Title != "コラム" {
+		t.Fatalf("ニュース、コラムの順を期待しましたが、%v を取得しました", output.Items)
+	}
+	if output.TargetDuration != 30*60 || output.TotalDuration != 29*60 {
+		t.Errorf("目標1800秒・合計1740秒を期待しましたが、%d秒・%d秒を取得しました", output.TargetDuration, output.TotalDuration)
+	}
+}
+
+func TestBuildQueueUsecase_Execute_InvalidDuration(t *testing.T) {
+	usecase := NewBuildQueueUsecase(nil, &mockAudioContentRepository{}, entities.DefaultQueueConfig())
+
+	for _, minutes := range []int{0, MinQueueMinutes - 1, MaxQueueMinutes + 1} {
+		_, err := usecase.Execute(context.Background(), &BuildQueueInput{UserID: 123, TargetMinutes: minutes})
+		if !errors.Is(err, ErrInvalidQueueDuration) {
+			t.Errorf("%d分: ErrInvalidQueueDurationを期待しましたが、%vを取得しました", minutes, err)
+		}
+	}
+}
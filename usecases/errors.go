@@ -0,0 +1,32 @@
+package usecases
+
+import "errors"
+
+var (
+	// ErrInvalidEvent 無効なイベントデータエラー
+	ErrInvalidEvent = errors.New("無効なイベントデータ")
+
+	// ErrInvalidUserID 無効なユーザーIDエラー
+	ErrInvalidUserID = errors.New("無効なユーザーID")
+
+	// ErrUserNotFound ユーザーが見つからないエラー
+	ErrUserNotFound = errors.New("ユーザーが見つかりません")
+
+	// ErrInvalidContentID 無効なコンテンツIDエラー
+	ErrInvalidContentID = errors.New("無効なコンテンツID")
+
+	// ErrContentNotFound コンテンツが見つからないエラー
+	ErrContentNotFound = errors.New("コンテンツが見つかりません")
+
+	// ErrInvalidCategoryID 無効なカテゴリIDエラー
+	ErrInvalidCategoryID = errors.New("無効なカテゴリID")
+
+	// ErrInvalidDismissal 無効な「興味なし」フィードバックエラー
+	ErrInvalidDismissal = errors.New("無効なフィードバック")
+
+	// ErrInvalidListeningContext 無効な利用状況エラー
+	ErrInvalidListeningContext = errors.New("無効な利用状況")
+
+	// ErrInvalidQueueDuration 無効な再生キューの目標時間エラー
+	ErrInvalidQueueDuration = errors.New("無効な目標時間")
+)
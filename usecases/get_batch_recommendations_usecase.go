@@ -0,0 +1,174 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+const (
+	// MaxBatchRecommendationUsers 1リクエストで指定できるユーザー数の上限
+	MaxBatchRecommendationUsers = 5000
+
+	// キャッシュミス時の同時生成数（DB接続プールを使い切らない程度）
+	batchRecommendationConcurrency = 4
+)
+
+// GetBatchRecommendationsInput 一括レコメンド取得の入力
+type GetBatchRecommendationsInput struct {
+	UserIDs []int
+	Limit   int
+}
+
+// UserRecommendationsResult ユーザーごとの取得結果
+type UserRecommendationsResult struct {
+	UserID          int                        `json:"userId"`
+	Recommendations []*entities.Recommendation `json:"recommendations,omitempty"`
+	Cached          bool                       `json:"cached"`
+	Error           string                     `json:"error,omitempty"`
+}
+
+// GetBatchRecommendationsOutput 一括レコメンド取得の出力
+type GetBatchRecommendationsOutput struct {
+	Results   []*UserRecommendationsResult `json:"results"`
+	Timestamp int64                        `json:"timestamp"`
+}
+
+// GetBatchRecommendationsUsecase 一括レコメンド取得ユースケース
+type GetBatchRecommendationsUsecase struct {
+	getRecommendationsUC *GetRecommendationsUsecase
+	cacheRepo            repositories.CacheRepository
+}
+
+// NewGetBatchRecommendationsUsecase コンストラクタ
+func NewGetBatchRecommendationsUsecase(
+	getRecommendationsUC *GetRecommendationsUsecase,
+	cacheRepo repositories.CacheRepository,
+) *GetBatchRecommendationsUsecase {
+	return &GetBatchRecommendationsUsecase{
+		getRecommendationsUC: getRecommendationsUC,
+		cacheRepo:            cacheRepo,
+	}
+}
+
+// Execute ユースケース実行（結果は重複を除いた入力順で返す）
+func (uc *GetBatchRecommendationsUsecase) Execute(
+	ctx context.Context,
+	input *GetBatchRecommendationsInput,
+) (*GetBatchRecommendationsOutput, error) {
+	userIDs := uniqueIDs(input.UserIDs)
+	if len(userIDs) == 0 {
+		return nil, fmt.Errorf("%w: ユーザーIDが指定されていません", ErrInvalidUserID)
+	}
+	if len(userIDs) > MaxBatchRecommendationUsers {
+		return nil, fmt.Errorf("%w: ユーザー数が上限(%d)を超えています", ErrInvalidUserID, MaxBatchRecommendationUsers)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = 20 // デフォルト値
+	}
+	if limit > MaxRecommendationLimit {
+		limit = MaxRecommendationLimit
+	}
+
+	results := make([]*UserRecommendationsResult, len(userIDs))
+
+	// キャッシュを一括取得
+	keys := make([]string, len(userIDs))
+	cached := make([]*GetRecommendationsOutput, len(userIDs))
+	dests := make([]interface{}, len(userIDs))
+	for i, userID := range userIDs {
+		keys[i] = recommendationCacheKey(userID)
+		cached[i] = &GetRecommendationsOutput{}
+		dests[i] = cached[i]
+	}
+
+	hits, err := uc.cacheRepo.GetMulti(ctx, keys, dests)
+	if err != nil {
+		// キャッシュ障害時は全件を生成にフォールバック
+		hits = make([]bool, len(userIDs))
+	}
+
+	var misses []int
+	for i, userID := range userIDs {
+		if hits[i] {
+			results[i] = &UserRecommendationsResult{
+				UserID:          userID,
+				Recommendations: limitRecommendations(cached[i].Recommendations, limit),
+				Cached:          true,
+			}
+			continue
+		}
+		if userID <= 0 {
+			results[i] = &UserRecommendationsResult{
+				UserID: userID,
+				Error:  fmt.Sprintf("%v: %d", ErrInvalidUserID, userID),
+			}
+			continue
+		}
+		misses = append(misses, i)
+	}
+
+	// キャッシュミス分を同時実行数を制限して生成（クライアントが切断したら残りは生成しない）
+	sem := make(chan struct{}, batchRecommendationConcurrency)
+	var wg sync.WaitGroup
+	for _, i := range misses {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+		}
+		if err := ctx.Err(); err != nil {
+			wg.Wait()
+			return nil, fmt.Errorf("一括レコメンドの生成を中断しました: %w", err)
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := &UserRecommendationsResult{UserID: userIDs[i]}
+			output, err := uc.getRecommendationsUC.Execute(ctx, &GetRecommendationsInput{
+				UserID: userIDs[i],
+				Limit:  limit,
+			})
+			if err != nil {
+				result.Error = err.Error()
+			} else {
+				result.Recommendations = output.Recommendations
+			}
+			results[i] = result
+		}(i)
+	}
+	wg.Wait()
+
+	return &GetBatchRecommendationsOutput{
+		Results:   results,
+		Timestamp: time.Now().Unix(),
+	}, nil
+}
+
+// uniqueIDs 順序を保って重複を除去
+func uniqueIDs(ids []int) []int {
+	seen := make(map[int]bool, len(ids))
+	unique := make([]int, 0, len(ids))
+	for _, id := range ids {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		unique = append(unique, id)
+	}
+	return unique
+}
+
+// limitRecommendations 指定した数だけレコメンドを取得
+func limitRecommendations(recs []*entities.Recommendation, limit int) []*entities.Recommendation {
+	if len(recs) <= limit {
+		return recs
+	}
+	return recs[:limit]
+}
@@ -0,0 +1,98 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+func TestGetBatchRecommendationsUsecase_Execute(t *testing.T) {
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			"recommendations:user:1": &GetRecommendationsOutput{
+				UserID: 1,
+				Recommendations: []*entities.Recommendation{
+					{UserID: 1, AudioContentID: 100, Score: 4.0, Reason: entities.ReasonPopular},
+				},
+			},
+		},
+	}
+
+	mockUser := &mockUserRepository{
+		user: &entities.User{ID: 2, Email: "test@example.com"},
+	}
+
+	mockBlender := &mockRecommendationBlender{
+		recommendations: []*entities.Recommendation{
+			{UserID: 2, AudioContentID: 200, Score: 3.0, Reason: entities.ReasonPopular},
+		},
+	}
+
+	getRecommendationsUC := NewGetRecommendationsUsecase(mockBlender, &mockRecommendationReranker{}, &mockContinueListeningService{}, mockCache, mockUser)
+	usecase := NewGetBatchRecommendationsUsecase(getRecommendationsUC, mockCache)
+
+	output, err := usecase.Execute(context.Background(), &GetBatchRecommendationsInput{
+		UserIDs: []int{1, 2, 2, 3, 0},
+		Limit:   20,
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 重複を除いた入力順
+	expectedUserIDs := []int{1, 2, 3, 0}
+	if len(output.Results) != len(expectedUserIDs) {
+		t.Fatalf("%d件の結果を期待しましたが、%d件を取得しました", len(expectedUserIDs), len(output.Results))
+	}
+	for i, result := range output.Results {
+		if result.UserID != expectedUserIDs[i] {
+			t.Errorf("インデックス %d: ユーザーID %d を期待しましたが、%d を取得しました", i, expectedUserIDs[i], result.UserID)
+		}
+	}
+
+	if !output.Results[0].Cached || len(output.Results[0].Recommendations) != 1 {
+		t.Errorf("ユーザー1はキャッシュから取得されることを期待しました: %+v", output.Results[0])
+	}
+
+	if output.Results[1].Cached || output.Results[1].Error != "" || len(output.Results[1].Recommendations) != 1 {
+		t.Errorf("ユーザー2は生成されることを期待しました: %+v", output.Results[1])
+	}
+
+	if output.Results[3].Error == "" {
+		t.Error("無効なユーザーIDに対するユーザー単位のエラーを期待しました")
+	}
+}
+
+func TestGetBatchRecommendationsUsecase_Execute_EmptyInput(t *testing.T) {
+	mockCache := &mockCacheRepository{}
+	getRecommendationsUC := NewGetRecommendationsUsecase(&mockRecommendationBlender{}, &mockRecommendationReranker{}, &mockContinueListeningService{}, mockCache, &mockUserRepository{})
+	usecase := NewGetBatchRecommendationsUsecase(getRecommendationsUC, mockCache)
+
+	if _, err := usecase.Execute(context.Background(), &GetBatchRecommendationsInput{}); err == nil {
+		t.Error("ユーザーID未指定に対するエラーを期待しましたが、エラーがありませんでした")
+	}
+}
+
+func TestGetBatchRecommendationsUsecase_Execute_Canceled(t *testing.T) {
+	mockCache := &mockCacheRepository{}
+	mockBlender := &mockRecommendationBlender{}
+	getRecommendationsUC := NewGetRecommendationsUsecase(mockBlender, &mockRecommendationReranker{}, &mockContinueListeningService{}, mockCache, &mockUserRepository{user: &entities.User{ID: 1}})
+	usecase := NewGetBatchRecommendationsUsecase(getRecommendationsUC, mockCache)
+
+	// 切断済みのリクエストでは生成を始めない
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	userIDs := make([]int, batchRecommendationConcurrency*2)
+	for i := range userIDs {
+		userIDs[i] = i + 1
+	}
+	_, err := usecase.Execute(ctx, &GetBatchRecommendationsInput{UserIDs: userIDs, Limit: 10})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("context.Canceledを期待しましたが、%vを取得しました", err)
+	}
+	if mockBlender.lastRequest != nil {
+		t.Error("切断後にレコメンドが生成されないことを期待しました")
+	}
+}
@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// GetContinueListeningInput 続きを聴く取得の入力
+type GetContinueListeningInput struct {
+	UserID int
+	Limit  int
+}
+
+// ContinueListeningItem 続きを聴くの項目
+type ContinueListeningItem struct {
+	AudioContentID int     `json:"audioContentId"`
+	Title          string  `json:"title"`
+	CategoryID     int     `json:"categoryId"`
+	AuthorID       int     `json:"authorId"`
+	Duration       int     `json:"duration"`
+	PlayedSeconds  int     `json:"playedSeconds"`
+	Progress       float64 `json:"progress"`
+	LastPlayedAt   int64   `json:"lastPlayedAt"`
+}
+
+// GetContinueListeningOutput 続きを聴く取得の出力
+type GetContinueListeningOutput struct {
+	UserID    int                      `json:"userId"`
+	Items     []*ContinueListeningItem `json:"items"`
+	Timestamp int64                    `json:"timestamp"`
+}
+
+// GetContinueListeningUsecase 続きを聴く（途中まで聴いたコンテンツ）取得ユースケース
+type GetContinueListeningUsecase struct {
+	continueListening ContinueListeningServiceInterface
+	userRepo          repositories.UserRepository
+}
+
+// NewGetContinueListeningUsecase コンストラクタ
+func NewGetContinueListeningUsecase(
+	continueListening ContinueListeningServiceInterface,
+	userRepo repositories.UserRepository,
+) *GetContinueListeningUsecase {
+	return &GetContinueListeningUsecase{
+		continueListening: continueListening,
+		userRepo:          userRepo,
+	}
+}
+
+// Execute ユースケース実行（再生のたびに変わるためキャッシュしない）
+func (uc *GetContinueListeningUsecase) Execute(ctx context.Context, input *GetContinueListeningInput) (*GetContinueListeningOutput, error) {
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
+	}
+
+	if input.Limit <= 0 {
+		input.Limit = 10 // デフォルト値
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, input.UserID)
+	}
+
+	items, err := uc.continueListening.Find(ctx, input.UserID, input.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("続きを聴くの取得に失敗しました: %w", err)
+	}
+
+	output := &GetContinueListeningOutput{
+		UserID:    input.UserID,
+		Items:     make([]*ContinueListeningItem, 0, len(items)),
+		Timestamp: time.Now().Unix(),
+	}
+	for _, item := range items {
+		output.Items = append(output.Items, &ContinueListeningItem{
+			AudioContentID: item.Content.ID,
+			Title:          item.Content.Title,
+			CategoryID:     item.Content.CategoryID,
+			AuthorID:       item.Content.AuthorID,
+			Duration:       item.Content.Duration,
+			PlayedSeconds:  item.PlayedSeconds,
+			Progress:       item.Progress,
+			LastPlayedAt:   item.LastPlayedAt.Unix(),
+		})
+	}
+
+	return output, nil
+}
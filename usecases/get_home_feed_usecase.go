@@ -0,0 +1,203 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"sync"
+	"time"
+)
+
+// 重複除外で減る分を見込んで、各棚に要求する件数の倍率
+const shelfCandidateFactor = 2
+
+// ShelfProviderInterface ホーム画面の棚を作るドメインサービスのインターフェース
+type ShelfProviderInterface interface {
+	Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error)
+}
+
+// DismissalLoaderInterface ユーザーが興味なしにした対象を取得するドメインサービスのインターフェース
+type DismissalLoaderInterface interface {
+	Load(ctx context.Context, userID int) (*entities.DismissalSet, error)
+}
+
+// GetHomeFeedInput ホームフィード取得の入力
+type GetHomeFeedInput struct {
+	UserID        int
+	ItemsPerShelf int
+}
+
+// HomeFeedItem 棚の項目
+type HomeFeedItem struct {
+	AudioContentID int                           `json:"audioContentId"`
+	Title          string                        `json:"title"`
+	CategoryID     int                           `json:"categoryId"`
+	AuthorID       int                           `json:"authorId"`
+	Duration       int                           `json:"duration"`
+	Score          float64                       `json:"score"`
+	Reason         entities.RecommendationReason `json:"reason"`
+}
+
+// HomeFeedShelf 棚（タイトルと、棚が選ばれた理由のメタデータ付き）
+type HomeFeedShelf struct {
+	ID             string             `json:"id"`
+	Type           entities.ShelfType `json:"type"`
+	Title          string             `json:"title"`
+	Explanation    string             `json:"explanation"`
+	SeedContentIDs []int              `json:"seedContentIds,omitempty"`
+	CategoryIDs    []int              `json:"categoryIds,omitempty"`
+	AuthorIDs      []int              `json:"authorIds,omitempty"`
+	Items          []*HomeFeedItem    `json:"items"`
+}
+
+// GetHomeFeedOutput ホームフィード取得の出力
+type GetHomeFeedOutput struct {
+	UserID    int              `json:"userId"`
+	Shelves   []*HomeFeedShelf `json:"shelves"`
+	Timestamp int64            `json:"timestamp"`
+}
+
+// GetHomeFeedUsecase 複数の棚からなるホームフィード取得ユースケース
+type GetHomeFeedUsecase struct {
+	providers        []ShelfProviderInterface
+	dismissals       DismissalLoaderInterface
+	audioContentRepo repositories.AudioContentRepository
+	userRepo         repositories.UserRepository
+}
+
+// NewGetHomeFeedUsecase コンストラクタ（providers の順に棚を並べる）
+func NewGetHomeFeedUsecase(
+	providers []ShelfProviderInterface,
+	dismissals DismissalLoaderInterface,
+	audioContentRepo repositories.AudioContentRepository,
+	userRepo repositories.UserRepository,
+) *GetHomeFeedUsecase {
+	return &GetHomeFeedUsecase{
+		providers:        providers,
+		dismissals:       dismissals,
+		audioContentRepo: audioContentRepo,
+		userRepo:         userRepo,
+	}
+}
+
+// Execute ユースケース実行（続きを聴くを含み再生のたびに変わるためキャッシュしない）
+func (uc *GetHomeFeedUsecase) Execute(ctx context.Context, input *GetHomeFeedInput) (*GetHomeFeedOutput, error) {
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
+	}
+
+	if input.ItemsPerShelf <= 0 {
+		input.ItemsPerShelf = 10 // デフォルト値
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, input.UserID)
+	}
+
+	shelves := uc.collectShelves(ctx, input.UserID, input.ItemsPerShelf*shelfCandidateFactor)
+
+	var ids []int
+	for _, shelf := range shelves {
+		for _, rec := range shelf.Items {
+			ids = append(ids, rec.AudioContentID)
+		}
+	}
+	contentByID := make(map[int]*entities.AudioContent, len(ids))
+	if len(ids) > 0 {
+		contents, err := uc.audioContentRepo.GetByIDs(ctx, uniqueIDs(ids))
+		if err != nil {
+			return nil, fmt.Errorf("コンテンツ情報の取得に失敗しました: %w", err)
+		}
+		for _, content := range contents {
+			contentByID[content.ID] = content
+		}
+	}
+
+	// 興味なしにした対象を表示しないよう、取得できなければエラーにする
+	dismissed, err := uc.dismissals.Load(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("興味なしの取得に失敗しました: %w", err)
+	}
+
+	output := &GetHomeFeedOutput{
+		UserID:    input.UserID,
+		Shelves:   []*HomeFeedShelf{},
+		Timestamp: time.Now().Unix(),
+	}
+
+	// 先に並ぶ棚を優先して、同じコンテンツは1つの棚にだけ表示する
+	shown := make(map[int]bool)
+	for _, shelf := range shelves {
+		var items []*HomeFeedItem
+		for _, rec := range shelf.Items {
+			content, ok := contentByID[rec.AudioContentID]
+			// 興味なしのカテゴリは棚の中で減点できないため除外
+			if !ok || shown[content.ID] || dismissed.Suppresses(content.ID, content) || dismissed.DismissedCategory(content) {
+				continue
+			}
+			items = append(items, &HomeFeedItem{
+				AudioContentID: content.ID,
+				Title:          content.Title,
+				CategoryID:     content.CategoryID,
+				AuthorID:       content.AuthorID,
+				Duration:       content.Duration,
+				Score:          rec.Score,
+				Reason:         rec.Reason,
+			})
+			if len(items) >= input.ItemsPerShelf {
+				break
+			}
+		}
+		if len(items) < shelf.MinItems() {
+			continue
+		}
+
+		for _, item := range items {
+			shown[item.AudioContentID] = true
+		}
+		output.Shelves = append(output.Shelves, &HomeFeedShelf{
+			ID:             shelf.ID,
+			Type:           shelf.Type,
+			Title:          shelf.Title,
+			Explanation:    shelf.Explanation,
+			SeedContentIDs: shelf.SeedContentIDs,
+			CategoryIDs:    shelf.CategoryIDs,
+			AuthorIDs:      shelf.AuthorIDs,
+			Items:          items,
+		})
+	}
+
+	return output, nil
+}
+
+// collectShelves 各提供元を並行実行して、提供元の順に棚を並べる（失敗した提供元はログに残してスキップ）
+func (uc *GetHomeFeedUsecase) collectShelves(ctx context.Context, userID int, limit int) []*entities.Shelf {
+	results := make([][]*entities.Shelf, len(uc.providers))
+
+	var wg sync.WaitGroup
+	for i, provider := range uc.providers {
+		wg.Add(1)
+		go func(i int, provider ShelfProviderInterface) {
+			defer wg.Done()
+			shelves, err := provider.Shelves(ctx, userID, limit)
+			if err != nil {
+				log.Printf("ホーム画面の棚（%T）の取得に失敗しました: %v", provider, err)
+				return
+			}
+			results[i] = shelves
+		}(i, provider)
+	}
+	wg.Wait()
+
+	var shelves []*entities.Shelf
+	for _, result := range results {
+		shelves = append(shelves, result...)
+	}
+	return shelves
+}
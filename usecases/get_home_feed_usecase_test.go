@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockShelfProvider struct {
+	shelves []*entities.Shelf
+	err     error
+}
+
+func (m *mockShelfProvider) Shelves(ctx context.Context, userID int, limit int) ([]*entities.Shelf, error) {
+	return m.shelves, m.err
+}
+
+type mockDismissalLoader struct {
+	dismissals []*entities.Dismissal
+	err        error
+}
+
+func (m *mockDismissalLoader) Load(ctx context.Context, userID int) (*entities.DismissalSet, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return entities.NewDismissalSet(m.dismissals), nil
+}
+
+func shelfOf(shelfType entities.ShelfType, ids ...int) *entities.Shelf {
+	shelf := &entities.Shelf{ID: string(shelfType), Type: shelfType, Title: string(shelfType)}
+	for _, id := range ids {
+		shelf.Items = append(shelf.Items, &entities.Recommendation{UserID: 123, AudioContentID: id, Score: 1.0})
+	}
+	return shelf
+}
+
+func TestGetHomeFeedUsecase_Execute(t *testing.T) {
+	contents := make(map[int]*entities.AudioContent)
+	for id := 1; id <= 9; id++ {
+		contents[id] = &entities.AudioContent{ID: id, Title: "コンテンツ", CategoryID: 10, AuthorID: 100 + id}
+	}
+
+	providers := []ShelfProviderInterface{
+		&mockShelfProvider{shelves: []*entities.Shelf{shelfOf(entities.ShelfContinueListening, 1)}},
+		&mockShelfProvider{err: errors.New("棚の取得に失敗")},
+		// 1 は先の棚に表示済み、9 は興味なしの作者
+		&mockShelfProvider{shelves: []*entities.Shelf{shelfOf(entities.ShelfTrendingInCategories, 1, 2, 3, 9, 4)}},
+		// 2・3 は表示済みで2件しか残らないため棚ごと表示しない
+		&mockShelfProvider{shelves: []*entities.Shelf{shelfOf(entities.ShelfNewFromAuthors, 2, 3, 5, 6)}},
+	}
+	dismissals := &mockDismissalLoader{
+		dismissals: []*entities.Dismissal{{UserID: 123, TargetType: entities.DismissalTargetAuthor, TargetID: 109}},
+	}
+
+	usecase := NewGetHomeFeedUsecase(
+		providers,
+		dismissals,
+		&mockAudioContentRepository{contents: contents},
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	output, err := usecase.Execute(context.Background(), &GetHomeFeedInput{UserID: 123, ItemsPerShelf: 10})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	expected := map[entities.ShelfType][]int{
+		entities.ShelfContinueListening:    {1},
+		entities.ShelfTrendingInCategories: {2, 3, 4},
+	}
+	if len(output.Shelves) != len(expected) {
+		t.Fatalf("%d個の棚を期待しましたが、%d個を取得しました", len(expected), len(output.Shelves))
+	}
+	for _, shelf := range output.Shelves {
+		ids := expected[shelf.Type]
+		if len(shelf.Items) != len(ids) {
+			t.Errorf("%s: %d件を期待しましたが、%d件を取得しました", shelf.Type, len(ids), len(shelf.Items))
+			continue
+		}
+		for i, item := range shelf.Items {
+			if item.AudioContentID != ids[i] {
+				t.Errorf("%s[%d]: コンテンツID %d を期待しましたが、%d を取得しました", shelf.Type, i, ids[i], item.AudioContentID)
+			}
+		}
+	}
+	if output.Shelves[0].Type != entities.ShelfContinueListening {
+		t.Errorf("先頭に続きを聴くの棚を期待しましたが、%sを取得しました", output.Shelves[0].Type)
+	}
+}
+
+func TestGetHomeFeedUsecase_Execute_UserNotFound(t *testing.T) {
+	usecase := NewGetHomeFeedUsecase(nil, &mockDismissalLoader{}, &mockAudioContentRepository{}, &mockUserRepository{})
+
+	_, err := usecase.Execute(context.Background(), &GetHomeFeedInput{UserID: 999})
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("ErrUserNotFoundを期待しましたが、%vを取得しました", err)
+	}
+}
+
+func TestGetHomeFeedUsecase_Execute_DismissalError(t *testing.T) {
+	usecase := NewGetHomeFeedUsecase(
+		[]ShelfProviderInterface{&mockShelfProvider{shelves: []*entities.Shelf{shelfOf(entities.ShelfContinueListening, 1)}}},
+		&mockDismissalLoader{err: errors.New("興味なしの取得に失敗")},
+		&mockAudioContentRepository{contents: map[int]*entities.AudioContent{1: {ID: 1}}},
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	// 興味なしにした対象を表示しないよう、取得できなければエラー
+	if _, err := usecase.Execute(context.Background(), &GetHomeFeedInput{UserID: 123}); err == nil {
+		t.Error("エラーを期待しましたが、nilを取得しました")
+	}
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"time"
+)
+
+const (
+	// セッションとして受け付けるコンテンツIDの最大数
+	maxSessionContentIDs = 200
+
+	// 興味なしの除外で減る分を見込んで、要求する件数の倍率
+	nextItemsCandidateFactor = 2
+)
+
+// SessionServiceInterface セッション内の次のコンテンツを予測するドメインサービスのインターフェース
+type SessionServiceInterface interface {
+	NextItems(ctx context.Context, session []int, limit int) ([]*entities.SimilarContent, error)
+}
+
+// GetNextItemsInput 次に聴くコンテンツ取得の入力
+type GetNextItemsInput struct {
+	UserID            int   // 興味なしにした対象を除くためのユーザー
+	SessionContentIDs []int // 現在のセッションで再生したコンテンツ（古い順）
+	Limit             int
+}
+
+// GetNextItemsOutput 次に聴くコンテンツ取得の出力
+type GetNextItemsOutput struct {
+	SessionContentIDs []int                 `json:"sessionContentIds"`
+	Items             []*SimilarContentItem `json:"items"`
+	Timestamp         int64                 `json:"timestamp"`
+}
+
+// GetNextItemsUsecase 次に聴くコンテンツ（自動再生）取得ユースケース
+type GetNextItemsUsecase struct {
+	sessionService SessionServiceInterface
+	dismissals     DismissalLoaderInterface
+}
+
+// NewGetNextItemsUsecase コンストラクタ
+func NewGetNextItemsUsecase(sessionService SessionServiceInterface, dismissals DismissalLoaderInterface) *GetNextItemsUsecase {
+	return &GetNextItemsUsecase{
+		sessionService: sessionService,
+		dismissals:     dismissals,
+	}
+}
+
+// Execute ユースケース実行
+func (uc *GetNextItemsUsecase) Execute(ctx context.Context, input *GetNextItemsInput) (*GetNextItemsOutput, error) {
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
+	}
+	if len(input.SessionContentIDs) == 0 {
+		return nil, fmt.Errorf("%w: セッションのコンテンツIDが必要です", ErrInvalidContentID)
+	}
+	if len(input.SessionContentIDs) > maxSessionContentIDs {
+		return nil, fmt.Errorf("%w: 最大%d件まで指定できます", ErrInvalidContentID, maxSessionContentIDs)
+	}
+	for _, id := range input.SessionContentIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidContentID, id)
+		}
+	}
+
+	if input.Limit <= 0 {
+		input.Limit = 10 // デフォルト値
+	}
+
+	// 興味なしにした対象を自動再生しないよう、取得できなければエラーにする
+	dismissed, err := uc.dismissals.Load(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("興味なしの取得に失敗しました: %w", err)
+	}
+
+	next, err := uc.sessionService.NextItems(ctx, input.SessionContentIDs, input.Limit*nextItemsCandidateFactor)
+	if err != nil {
+		return nil, fmt.Errorf("次に聴くコンテンツの取得に失敗しました: %w", err)
+	}
+
+	items := make([]*SimilarContentItem, 0, input.Limit)
+	for _, n := range next {
+		// 興味なしのカテゴリは減点しても順位が変わるだけで自動再生されうるため除外
+		if dismissed.Suppresses(n.Content.ID, n.Content) || dismissed.DismissedCategory(n.Content) {
+			continue
+		}
+		if len(items) >= input.Limit {
+			break
+		}
+		items = append(items, &SimilarContentItem{
+			AudioContentID: n.Content.ID,
+			Title:          n.Content.Title,
+			CategoryID:     n.Content.CategoryID,
+			AuthorID:       n.Content.AuthorID,
+			Duration:       n.Content.Duration,
+			Score:          n.Score,
+			Reasons:        n.Reasons,
+		})
+	}
+
+	return &GetNextItemsOutput{
+		SessionContentIDs: input.SessionContentIDs,
+		Items:             items,
+		Timestamp:         time.Now().Unix(),
+	}, nil
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockSessionService struct {
+	next []*entities.SimilarContent
+}
+
+func (m *mockSessionService) NextItems(ctx context.Context, session []int, limit int) ([]*entities.SimilarContent, error) {
+	if len(m.next) > limit {
+		return m.next[:limit], nil
+	}
+	return m.next, nil
+}
+
+func TestGetNextItemsUsecase_Execute(t *testing.T) {
+	sessionService := &mockSessionService{
+		next: []*entities.SimilarContent{
+			{Content: &entities.AudioContent{ID: 11, CategoryID: 10, AuthorID: 100}, Score: 0.9},
+			{Content: &entities.AudioContent{ID: 12, CategoryID: 10, AuthorID: 200}, Score: 0.8}, // 興味なしの作者
+			{Content: &entities.AudioContent{ID: 13, CategoryID: 30, AuthorID: 300}, Score: 0.7}, // 興味なしのカテゴリ
+			{Content: &entities.AudioContent{ID: 14, CategoryID: 10, AuthorID: 400}, Score: 0.6},
+		},
+	}
+	dismissals := &mockDismissalLoader{
+		dismissals: []*entities.Dismissal{
+			{UserID: 1, TargetType: entities.DismissalTargetAuthor, TargetID: 200},
+			{UserID: 1, TargetType: entities.DismissalTargetCategory, TargetID: 30},
+		},
+	}
+
+	usecase := NewGetNextItemsUsecase(sessionService, dismissals)
+	output, err := usecase.Execute(context.Background(), &GetNextItemsInput{UserID: 1, SessionContentIDs: []int{1, 2}, Limit: 2})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	expected := []int{11, 14}
+	if len(output.Items) != len(expected) {
+		t.Fatalf("%d件を期待しましたが、%d件を取得しました", len(expected), len(output.Items))
+	}
+	for i, item := range output.Items {
+		if item.AudioContentID != expected[i] {
+			t.Errorf("インデックス %d: コンテンツID %d を期待しましたが、%d を取得しました", i, expected[i], item.AudioContentID)
+		}
+	}
+}
+
+func TestGetNextItemsUsecase_Execute_InvalidUserID(t *testing.T) {
+	usecase := NewGetNextItemsUsecase(&mockSessionService{}, &mockDismissalLoader{})
+
+	_, err := usecase.Execute(context.Background(), &GetNextItemsInput{SessionContentIDs: []int{1}})
+	if !errors.Is(err, ErrInvalidUserID) {
+		t.Errorf("ErrInvalidUserIDを期待しましたが、%vを取得しました", err)
+	}
+}
@@ -8,18 +8,78 @@ import (
 	"time"
 )
 
-// RecommendationAlgorithmServiceInterface ドメインサービスのインターフェース
-type RecommendationAlgorithmServiceInterface interface {
-	GenerateCollaborativeRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error)
-	GenerateContentBasedRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error)
-	GeneratePopularityBasedRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error)
-	GenerateNewContentRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error)
+// RecommendationBlenderInterface 複数ソースをブレンドするドメインサービスのインターフェース
+type RecommendationBlenderInterface interface {
+	Blend(ctx context.Context, req *entities.RecommendationRequest) (*entities.RecommendationSet, error)
 }
 
+// RecommendationRerankerInterface 最終順位を並べ替えるドメインサービスのインターフェース
+type RecommendationRerankerInterface interface {
+	Rerank(ctx context.Context, recs []*entities.Recommendation, limit int) ([]*entities.Recommendation, error)
+}
+
+// ContinueListeningServiceInterface 途中まで聴いたコンテンツを探すドメインサービスのインターフェース
+type ContinueListeningServiceInterface interface {
+	Find(ctx context.Context, userID int, limit int) ([]*entities.ContinueListeningItem, error)
+}
+
+// メインフィードの先頭に挿入する「続きを聴く」の枠数
+const continueListeningFeedSlots = 1
+
+// MaxRecommendationLimit 1リクエストで取得できる件数の上限（各ソースの問い合わせと多様性リランキングの計算量が件数に比例するため）
+const MaxRecommendationLimit = 100
+
 // GetRecommendationsInput レコメンド取得の入力
 type GetRecommendationsInput struct {
-	UserID int
-	Limit  int
+	UserID                   int
+	Limit                    int
+	Explain                  bool                   // 説明モード（キャッシュを使わず、各項目の寄与を含めて返す）
+	IncludeContinueListening bool                   // 先頭に「続きを聴く」の枠を挿入
+	Context                  *ListeningContextInput // 利用状況（指定があれば時間帯の傾向で補正し、キャッシュを使わない）
+	SkipCache                bool                   // キャッシュを読み書きしない（フィードと件数の異なる候補を作る場合）
+}
+
+// ListeningContextInput 利用状況の入力（すべて任意）
+type ListeningContextInput struct {
+	LocalTime string // RFC3339の現地時刻（例: 2024-04-01T08:30:00+09:00）。省略時は現在時刻
+	Timezone  string // IANAのタイムゾーン名（例: Asia/Tokyo）。省略時は LocalTime のオフセット（両方省略時はUTC）
+	Device    string // 端末種別（mobile, desktop, smart_speaker, car）
+}
+
+// toListeningContext 入力を検証して利用状況に変換
+func (in *ListeningContextInput) toListeningContext(now time.Time) (*entities.ListeningContext, error) {
+	localTime := now
+	if in.LocalTime != "" {
+		parsed, err := time.Parse(time.RFC3339, in.LocalTime)
+		if err != nil {
+			return nil, fmt.Errorf("%w: localTime %q", ErrInvalidListeningContext, in.LocalTime)
+		}
+		localTime = parsed
+	}
+
+	if in.Timezone != "" {
+		loc, err := time.LoadLocation(in.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("%w: timezone %q", ErrInvalidListeningContext, in.Timezone)
+		}
+		localTime = localTime.In(loc)
+	} else if in.LocalTime == "" {
+		// 端末だけの指定でも長さの補正は使えるよう、時刻の指定がなければUTCの現在時刻とみなす
+		localTime = now.UTC()
+	}
+
+	device := entities.DeviceUnknown
+	if in.Device != "" {
+		device = entities.DeviceType(in.Device)
+		if !device.IsValid() {
+			return nil, fmt.Errorf("%w: device %q", ErrInvalidListeningContext, in.Device)
+		}
+	}
+
+	return &entities.ListeningContext{
+		LocalTime: localTime,
+		Device:    device,
+	}, nil
 }
 
 // GetRecommendationsOutput レコメンド取得の出力
@@ -29,23 +89,34 @@ type GetRecommendationsOutput struct {
 	Timestamp       int64                     `json:"timestamp"`
 }
 
+// recommendationCacheKey ユーザーごとのレコメンドキャッシュキー
+func recommendationCacheKey(userID int) string {
+	return fmt.Sprintf("recommendations:user:%d", userID)
+}
+
 // GetRecommendationsUsecase レコメンド取得ユースケース
 type GetRecommendationsUsecase struct {
-	algorithmService RecommendationAlgorithmServiceInterface
-	cacheRepo        repositories.CacheRepository
-	userRepo         repositories.UserRepository
+	blender           RecommendationBlenderInterface
+	reranker          RecommendationRerankerInterface
+	continueListening ContinueListeningServiceInterface
+	cacheRepo         repositories.CacheRepository
+	userRepo          repositories.UserRepository
 }
 
 // NewGetRecommendationsUsecase コンストラクタ
 func NewGetRecommendationsUsecase(
-	algorithmService RecommendationAlgorithmServiceInterface,
+	blender RecommendationBlenderInterface,
+	reranker RecommendationRerankerInterface,
+	continueListening ContinueListeningServiceInterface,
 	cacheRepo repositories.CacheRepository,
 	userRepo repositories.UserRepository,
 ) *GetRecommendationsUsecase {
 	return &GetRecommendationsUsecase{
-		algorithmService: algorithmService,
-		cacheRepo:        cacheRepo,
-		userRepo:         userRepo,
+		blender:           blender,
+		reranker:          reranker,
+		continueListening: continueListening,
+		cacheRepo:         cacheRepo,
+		userRepo:          userRepo,
 	}
 }
 
@@ -53,12 +124,15 @@ func NewGetRecommendationsUsecase(
 func (uc *GetRecommendationsUsecase) Execute(ctx context.Context, input *GetRecommendationsInput) (*GetRecommendationsOutput, error) {
 	// 入力検証
 	if input.UserID <= 0 {
-		return nil, fmt.Errorf("無効なユーザーID: %d", input.UserID)
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
 	}
 	
 	if input.Limit <= 0 {
 		input.Limit = 20 // デフォルト値
 	}
+	if input.Limit > MaxRecommendationLimit {
+		input.Limit = MaxRecommendationLimit
+	}
 
 	// ユーザーの存在確認
 	user, err := uc.userRepo.GetByID(ctx, input.UserID)
@@ -66,77 +140,48 @@ func (uc *GetRecommendationsUsecase) Execute(ctx context.Context, input *GetReco
 		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
 	}
 	if user == nil {
-		return nil, fmt.Errorf("ユーザーが見つかりません: %d", input.UserID)
-	}
-
-	// キャッシュ確認
-	cacheKey := fmt.Sprintf("recommendations:user:%d", input.UserID)
-	var cachedOutput GetRecommendationsOutput
-	if err := uc.cacheRepo.Get(ctx, cacheKey, &cachedOutput); err == nil {
-		return &cachedOutput, nil
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, input.UserID)
 	}
 
-	// レコメンド生成セット作成
-	recSet := &entities.RecommendationSet{
-		UserID:      input.UserID,
-		GeneratedAt: time.Now(),
-	}
-
-	// 1. 協調フィルタリング (40%)
-	collaborative, err := uc.algorithmService.GenerateCollaborativeRecommendations(
-		ctx, 
-		input.UserID, 
-		input.Limit/2,
-	)
-	if err == nil {
-		for _, rec := range collaborative {
-			rec.GeneratedAt = time.Now()
-			recSet.AddRecommendation(rec)
-		}
-	}
-
-	// 2. コンテンツベース (30%)
-	contentBased, err := uc.algorithmService.GenerateContentBasedRecommendations(
-		ctx, 
-		input.UserID, 
-		input.Limit/3,
-	)
-	if err == nil {
-		for _, rec := range contentBased {
-			rec.GeneratedAt = time.Now()
-			recSet.AddRecommendation(rec)
+	var listening *entities.ListeningContext
+	if input.Context != nil {
+		listening, err = input.Context.toListeningContext(time.Now())
+		if err != nil {
+			return nil, err
 		}
 	}
 
-	// 3. 人気度ベース (20%)
-	popular, err := uc.algorithmService.GeneratePopularityBasedRecommendations(
-		ctx, 
-		input.UserID, 
-		input.Limit/5,
-	)
-	if err == nil {
-		for _, rec := range popular {
-			rec.GeneratedAt = time.Now()
-			recSet.AddRecommendation(rec)
+	// キャッシュ確認（説明モードではキャッシュに寄与情報がなく、利用状況の指定があると結果が時間帯で変わるため常に生成）
+	cacheKey := recommendationCacheKey(input.UserID)
+	useCache := !input.Explain && !input.SkipCache && listening == nil
+	if useCache {
+		var cachedOutput GetRecommendationsOutput
+		if err := uc.cacheRepo.Get(ctx, cacheKey, &cachedOutput); err == nil {
+			// キャッシュキーは件数を含まないため、多く保存されていても要求した件数に揃える
+			if len(cachedOutput.Recommendations) > input.Limit {
+				cachedOutput.Recommendations = cachedOutput.Recommendations[:input.Limit]
+			}
+			return uc.withContinueListening(ctx, input, &cachedOutput), nil
 		}
 	}
 
-	// 4. 新着コンテンツ (10%)
-	newContent, err := uc.algorithmService.GenerateNewContentRecommendations(
-		ctx, 
-		input.UserID, 
-		input.Limit/10,
-	)
-	if err == nil {
-		for _, rec := range newContent {
-			rec.GeneratedAt = time.Now()
-			recSet.AddRecommendation(rec)
-		}
+	// 登録済みソースの候補をブレンド
+	recSet, err := uc.blender.Blend(ctx, &entities.RecommendationRequest{
+		UserID:  input.UserID,
+		Limit:   input.Limit,
+		Context: listening,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("レコメンドの生成に失敗しました: %w", err)
 	}
 
-	// 重複除去とソート
+	// ソート（重複はブレンド時に統合済み）してから多様性を考慮して並べ替え
 	recSet.SortByScore()
-	finalRecommendations := recSet.Limit(input.Limit)
+	finalRecommendations, err := uc.reranker.Rerank(ctx, recSet.Recommendations, input.Limit)
+	if err != nil {
+		// 並べ替えに失敗した場合はスコア順で続行
+		finalRecommendations = recSet.Limit(input.Limit)
+	}
 
 	// 結果作成
 	output := &GetRecommendationsOutput{
@@ -146,9 +191,56 @@ func (uc *GetRecommendationsUsecase) Execute(ctx context.Context, input *GetReco
 	}
 
 	// キャッシュに保存
-	if err := uc.cacheRepo.Set(ctx, cacheKey, output, time.Hour); err != nil {
-		// ログ出力のみで続行
+	if useCache {
+		if err := uc.cacheRepo.Set(ctx, cacheKey, output, time.Hour); err != nil {
+			// ログ出力のみで続行
+		}
 	}
 
-	return output, nil
+	return uc.withContinueListening(ctx, input, output), nil
+}
+
+// withContinueListening 指定があれば先頭に「続きを聴く」の枠を挿入（キャッシュとは別に毎回取得）
+func (uc *GetRecommendationsUsecase) withContinueListening(
+	ctx context.Context,
+	input *GetRecommendationsInput,
+	output *GetRecommendationsOutput,
+) *GetRecommendationsOutput {
+	if !input.IncludeContinueListening {
+		return output
+	}
+
+	items, err := uc.continueListening.Find(ctx, input.UserID, continueListeningFeedSlots)
+	if err != nil || len(items) == 0 {
+		return output
+	}
+
+	pinned := make(map[int]bool, len(items))
+	recommendations := make([]*entities.Recommendation, 0, len(output.Recommendations)+len(items))
+	for _, item := range items {
+		rec := &entities.Recommendation{
+			UserID:         input.UserID,
+			AudioContentID: item.Content.ID,
+			Score:          item.Progress,
+			Reason:         entities.ReasonContinueListening,
+			GeneratedAt:    time.Now(),
+		}
+		rec.AddAdjustment(entities.AdjustmentPinned, "続きを聴く枠（進捗%.0f%%）", item.Progress*100)
+		pinned[item.Content.ID] = true
+		recommendations = append(recommendations, rec)
+	}
+	for _, rec := range output.Recommendations {
+		if !pinned[rec.AudioContentID] {
+			recommendations = append(recommendations, rec)
+		}
+	}
+	if len(recommendations) > input.Limit {
+		recommendations = recommendations[:input.Limit]
+	}
+
+	return &GetRecommendationsOutput{
+		UserID:          output.UserID,
+		Recommendations: recommendations,
+		Timestamp:       output.Timestamp,
+	}
 }
\ No newline at end of file
@@ -4,17 +4,21 @@ import (
 	"context"
 	"errors"
 	"mimiru-ai/domain/entities"
+	"sync"
 	"testing"
 	"time"
 )
 
 // モックリポジトリとサービス
 type mockCacheRepository struct {
+	mu   sync.Mutex
 	data map[string]interface{}
 	err  error
 }
 
 func (m *mockCacheRepository) Get(ctx context.Context, key string, dest interface{}) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.err != nil {
 		return m.err
 	}
@@ -27,7 +31,17 @@ func (m *mockCacheRepository) Get(ctx context.Context, key string, dest interfac
 	return errors.New("キャッシュミス")
 }
 
+func (m *mockCacheRepository) GetMulti(ctx context.Context, keys []string, dests []interface{}) ([]bool, error) {
+	hits := make([]bool, len(keys))
+	for i, key := range keys {
+		hits[i] = m.Get(ctx, key, dests[i]) == nil
+	}
+	return hits, nil
+}
+
 func (m *mockCacheRepository) Set(ctx context.Context, key string, value interface{}, expiration time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.err != nil {
 		return m.err
 	}
@@ -38,7 +52,25 @@ func (m *mockCacheRepository) Set(ctx context.Context, key string, value interfa
 	return nil
 }
 
+func (m *mockCacheRepository) SetIfNotExists(ctx context.Context, key string, value interface{}, expiration time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.err != nil {
+		return false, m.err
+	}
+	if m.data == nil {
+		m.data = make(map[string]interface{})
+	}
+	if _, exists := m.data[key]; exists {
+		return false, nil
+	}
+	m.data[key] = value
+	return true, nil
+}
+
 func (m *mockCacheRepository) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.data != nil {
 		delete(m.data, key)
 	}
@@ -46,6 +78,8 @@ func (m *mockCacheRepository) Delete(ctx context.Context, key string) error {
 }
 
 func (m *mockCacheRepository) Exists(ctx context.Context, key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	if m.data == nil {
 		return false, nil
 	}
@@ -54,8 +88,9 @@ func (m *mockCacheRepository) Exists(ctx context.Context, key string) (bool, err
 }
 
 type mockUserRepository struct {
-	user *entities.User
-	err  error
+	user            *entities.User
+	err             error
+	savedCategories []int
 }
 
 func (m *mockUserRepository) GetByID(ctx context.Context, userID int) (*entities.User, error) {
@@ -73,40 +108,60 @@ func (m *mockUserRepository) Save(ctx context.Context, user *entities.User) erro
 	return nil
 }
 
-type mockRecommendationAlgorithmService struct {
-	collaborative []*entities.Recommendation
-	contentBased  []*entities.Recommendation
-	popular       []*entities.Recommendation
-	newContent    []*entities.Recommendation
-	err           error
-}
-
-func (m *mockRecommendationAlgorithmService) GenerateCollaborativeRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error) {
+func (m *mockUserRepository) SavePreferredCategories(ctx context.Context, userID int, categoryIDs []int) error {
 	if m.err != nil {
-		return nil, m.err
+		return m.err
 	}
-	return m.collaborative, nil
+	m.savedCategories = categoryIDs
+	return nil
+}
+
+type mockRecommendationBlender struct {
+	recommendations []*entities.Recommendation
+	err             error
+	lastRequest     *entities.RecommendationRequest
 }
 
-func (m *mockRecommendationAlgorithmService) GenerateContentBasedRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error) {
+func (m *mockRecommendationBlender) Blend(ctx context.Context, req *entities.RecommendationRequest) (*entities.RecommendationSet, error) {
+	m.lastRequest = req
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.contentBased, nil
+	recSet := &entities.RecommendationSet{UserID: req.UserID, GeneratedAt: time.Now()}
+	for _, rec := range m.recommendations {
+		copied := *rec
+		recSet.AddRecommendation(&copied)
+	}
+	return recSet, nil
+}
+
+type mockRecommendationReranker struct {
+	err error
 }
 
-func (m *mockRecommendationAlgorithmService) GeneratePopularityBasedRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error) {
+func (m *mockRecommendationReranker) Rerank(ctx context.Context, recs []*entities.Recommendation, limit int) ([]*entities.Recommendation, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.popular, nil
+	if len(recs) > limit {
+		return recs[:limit], nil
+	}
+	return recs, nil
+}
+
+type mockContinueListeningService struct {
+	items []*entities.ContinueListeningItem
+	err   error
 }
 
-func (m *mockRecommendationAlgorithmService) GenerateNewContentRecommendations(ctx context.Context, userID int, limit int) ([]*entities.Recommendation, error) {
+func (m *mockContinueListeningService) Find(ctx context.Context, userID int, limit int) ([]*entities.ContinueListeningItem, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
-	return m.newContent, nil
+	if len(m.items) > limit {
+		return m.items[:limit], nil
+	}
+	return m.items, nil
 }
 
 func TestGetRecommendationsUsecase_Execute_WithCache(t *testing.T) {
@@ -134,10 +189,12 @@ func TestGetRecommendationsUsecase_Execute_WithCache(t *testing.T) {
 		user: &entities.User{ID: 123, Email: "test@example.com"},
 	}
 
-	mockAlgorithm := &mockRecommendationAlgorithmService{}
+	mockBlender := &mockRecommendationBlender{}
 
 	usecase := NewGetRecommendationsUsecase(
-		mockAlgorithm,
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
 		mockCache,
 		mockUser,
 	)
@@ -162,13 +219,44 @@ func TestGetRecommendationsUsecase_Execute_WithCache(t *testing.T) {
 	}
 }
 
+func TestGetRecommendationsUsecase_Execute_CacheHitTruncatesToLimit(t *testing.T) {
+	var cached []*entities.Recommendation
+	for id := 1; id <= 5; id++ {
+		cached = append(cached, &entities.Recommendation{UserID: 123, AudioContentID: id, Score: 1.0})
+	}
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			recommendationCacheKey(123): &GetRecommendationsOutput{UserID: 123, Recommendations: cached},
+		},
+	}
+
+	usecase := NewGetRecommendationsUsecase(
+		&mockRecommendationBlender{},
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		mockCache,
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	// キャッシュキーは件数を含まないため、多く保存されていても要求した件数だけ返す
+	output, err := usecase.Execute(context.Background(), &GetRecommendationsInput{UserID: 123, Limit: 2})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	if len(output.Recommendations) != 2 {
+		t.Errorf("2件のレコメンドを期待しましたが、%d件を取得しました", len(output.Recommendations))
+	}
+}
+
 func TestGetRecommendationsUsecase_Execute_InvalidInput(t *testing.T) {
 	mockCache := &mockCacheRepository{}
 	mockUser := &mockUserRepository{}
-	mockAlgorithm := &mockRecommendationAlgorithmService{}
+	mockBlender := &mockRecommendationBlender{}
 
 	usecase := NewGetRecommendationsUsecase(
-		mockAlgorithm,
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
 		mockCache,
 		mockUser,
 	)
@@ -195,10 +283,12 @@ func TestGetRecommendationsUsecase_Execute_UserNotFound(t *testing.T) {
 		user: nil, // ユーザーが見つからない
 	}
 
-	mockAlgorithm := &mockRecommendationAlgorithmService{}
+	mockBlender := &mockRecommendationBlender{}
 
 	usecase := NewGetRecommendationsUsecase(
-		mockAlgorithm,
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
 		mockCache,
 		mockUser,
 	)
@@ -224,23 +314,19 @@ func TestGetRecommendationsUsecase_Execute_GenerateRecommendations(t *testing.T)
 		user: &entities.User{ID: 123, Email: "test@example.com"},
 	}
 
-	mockAlgorithm := &mockRecommendationAlgorithmService{
-		collaborative: []*entities.Recommendation{
-			{UserID: 123, AudioContentID: 1, Score: 4.0, Reason: entities.ReasonSimilarUsers},
-		},
-		contentBased: []*entities.Recommendation{
-			{UserID: 123, AudioContentID: 2, Score: 3.5, Reason: entities.ReasonContentBased},
-		},
-		popular: []*entities.Recommendation{
+	mockBlender := &mockRecommendationBlender{
+		recommendations: []*entities.Recommendation{
 			{UserID: 123, AudioContentID: 3, Score: 3.0, Reason: entities.ReasonPopular},
-		},
-		newContent: []*entities.Recommendation{
+			{UserID: 123, AudioContentID: 1, Score: 4.0, Reason: entities.ReasonSimilarUsers},
 			{UserID: 123, AudioContentID: 4, Score: 2.5, Reason: entities.ReasonNewContent},
+			{UserID: 123, AudioContentID: 2, Score: 3.5, Reason: entities.ReasonContentBased},
 		},
 	}
 
 	usecase := NewGetRecommendationsUsecase(
-		mockAlgorithm,
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
 		mockCache,
 		mockUser,
 	)
@@ -270,4 +356,279 @@ func TestGetRecommendationsUsecase_Execute_GenerateRecommendations(t *testing.T)
 			t.Error("レコメンドがスコアの降順でソートされていません")
 		}
 	}
-}
\ No newline at end of file
+}
+
+func TestGetRecommendationsUsecase_Execute_RerankFailure(t *testing.T) {
+	mockCache := &mockCacheRepository{}
+	mockUser := &mockUserRepository{
+		user: &entities.User{ID: 123, Email: "test@example.com"},
+	}
+	mockBlender := &mockRecommendationBlender{
+		recommendations: []*entities.Recommendation{
+			{UserID: 123, AudioContentID: 1, Score: 1.0, Reason: entities.ReasonPopular},
+			{UserID: 123, AudioContentID: 2, Score: 3.0, Reason: entities.ReasonPopular},
+			{UserID: 123, AudioContentID: 3, Score: 2.0, Reason: entities.ReasonPopular},
+		},
+	}
+
+	usecase := NewGetRecommendationsUsecase(
+		mockBlender,
+		&mockRecommendationReranker{err: errors.New("コンテンツ取得エラー")},
+		&mockContinueListeningService{},
+		mockCache,
+		mockUser,
+	)
+
+	output, err := usecase.Execute(context.Background(), &GetRecommendationsInput{UserID: 123, Limit: 2})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 並べ替えに失敗してもスコア順で返す
+	if len(output.Recommendations) != 2 {
+		t.Fatalf("2件のレコメンドを期待しましたが、%d件を取得しました", len(output.Recommendations))
+	}
+	if output.Recommendations[0].AudioContentID != 2 || output.Recommendations[1].AudioContentID != 3 {
+		t.Errorf("スコア順 [2 3] を期待しましたが、[%d %d] を取得しました",
+			output.Recommendations[0].AudioContentID, output.Recommendations[1].AudioContentID)
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_ExplainBypassesCache(t *testing.T) {
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			"recommendations:user:123": &GetRecommendationsOutput{UserID: 123},
+		},
+	}
+	mockUser := &mockUserRepository{
+		user: &entities.User{ID: 123, Email: "test@example.com"},
+	}
+	mockBlender := &mockRecommendationBlender{
+		recommendations: []*entities.Recommendation{
+			{UserID: 123, AudioContentID: 1, Score: 1.0, Reason: entities.ReasonPopular},
+		},
+	}
+
+	usecase := NewGetRecommendationsUsecase(
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		mockCache,
+		mockUser,
+	)
+
+	output, err := usecase.Execute(context.Background(), &GetRecommendationsInput{UserID: 123, Limit: 20, Explain: true})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// キャッシュではなく新たに生成した結果を返す
+	if len(output.Recommendations) != 1 {
+		t.Errorf("1件のレコメンドを期待しましたが、%d件を取得しました", len(output.Recommendations))
+	}
+
+	// 説明モードの結果はキャッシュしない
+	cached := mockCache.data["recommendations:user:123"].(*GetRecommendationsOutput)
+	if len(cached.Recommendations) != 0 {
+		t.Error("説明モードの結果がキャッシュに保存されています")
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_ListeningContext(t *testing.T) {
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			"recommendations:user:123": &GetRecommendationsOutput{UserID: 123},
+		},
+	}
+	mockBlender := &mockRecommendationBlender{
+		recommendations: []*entities.Recommendation{
+			{UserID: 123, AudioContentID: 1, Score: 1.0, Reason: entities.ReasonPopular},
+		},
+	}
+
+	usecase := NewGetRecommendationsUsecase(
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		mockCache,
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	output, err := usecase.Execute(context.Background(), &GetRecommendationsInput{
+		UserID: 123,
+		Limit:  20,
+		Context: &ListeningContextInput{
+			LocalTime: "2024-04-01T23:30:00Z",
+			Timezone:  "Asia/Tokyo",
+			Device:    "mobile",
+		},
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// キャッシュではなく利用状況を渡して生成した結果を返す
+	if len(output.Recommendations) != 1 {
+		t.Errorf("1件のレコメンドを期待しましたが、%d件を取得しました", len(output.Recommendations))
+	}
+	listening := mockBlender.lastRequest.Context
+	if listening == nil {
+		t.Fatal("利用状況がブレンダーに渡されることを期待しましたが、nilを取得しました")
+	}
+	// UTCの月曜23:30は東京の火曜8:30
+	expectedSlot := entities.TimeSlot{DayPart: entities.DayPartMorning}
+	if listening.Slot() != expectedSlot || listening.Device != entities.DeviceMobile {
+		t.Errorf("%v（mobile）を期待しましたが、%v（%s）を取得しました", expectedSlot, listening.Slot(), listening.Device)
+	}
+
+	// 時間帯で変わる結果はキャッシュしない
+	cached := mockCache.data["recommendations:user:123"].(*GetRecommendationsOutput)
+	if len(cached.Recommendations) != 0 {
+		t.Error("利用状況を指定した結果がキャッシュに保存されています")
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_LimitIsCapped(t *testing.T) {
+	mockBlender := &mockRecommendationBlender{}
+	usecase := NewGetRecommendationsUsecase(
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		&mockCacheRepository{},
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	if _, err := usecase.Execute(context.Background(), &GetRecommendationsInput{UserID: 123, Limit: 1000000}); err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+	if mockBlender.lastRequest.Limit != MaxRecommendationLimit {
+		t.Errorf("上限の%d件を期待しましたが、%d件を取得しました", MaxRecommendationLimit, mockBlender.lastRequest.Limit)
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_DeviceOnlyContext(t *testing.T) {
+	mockBlender := &mockRecommendationBlender{}
+	usecase := NewGetRecommendationsUsecase(
+		mockBlender,
+		&mockRecommendationReranker{},
+		&mockContinueListeningService{},
+		&mockCacheRepository{},
+		&mockUserRepository{user: &entities.User{ID: 123}},
+	)
+
+	_, err := usecase.Execute(context.Background(), &GetRecommendationsInput{
+		UserID:  123,
+		Context: &ListeningContextInput{Device: "mobile"},
+	})
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	// 時刻の指定がなければUTCの現在時刻で、端末はそのまま渡す
+	listening := mockBlender.lastRequest.Context
+	if listening == nil {
+		t.Fatal("利用状況がブレンダーに渡されることを期待しましたが、nilを取得しました")
+	}
+	if listening.Device != entities.DeviceMobile || listening.LocalTime.Location() != time.UTC {
+		t.Errorf("UTCの現在時刻（mobile）を期待しましたが、%v（%s）を取得しました", listening.LocalTime, listening.Device)
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_InvalidListeningContext(t *testing.T) {
+	tests := []struct {
+		name    string
+		context *ListeningContextInput
+	}{
+		{name: "不正な時刻", context: &ListeningContextInput{LocalTime: "明日の朝"}},
+		{name: "不明なタイムゾーン", context: &ListeningContextInput{Timezone: "Mars/Olympus"}},
+		{name: "不明な端末", context: &ListeningContextInput{Timezone: "Asia/Tokyo", Device: "watch"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewGetRecommendationsUsecase(
+				&mockRecommendationBlender{},
+				&mockRecommendationReranker{},
+				&mockContinueListeningService{},
+				&mockCacheRepository{},
+				&mockUserRepository{user: &entities.User{ID: 123}},
+			)
+
+			_, err := usecase.Execute(context.Background(), &GetRecommendationsInput{UserID: 123, Context: tt.context})
+			if !errors.Is(err, ErrInvalidListeningContext) {
+				t.Errorf("ErrInvalidListeningContextを期待しましたが、%vを取得しました", err)
+			}
+		})
+	}
+}
+
+func TestGetRecommendationsUsecase_Execute_ContinueListeningSlot(t *testing.T) {
+	cachedOutput := &GetRecommendationsOutput{
+		UserID: 123,
+		Recommendations: []*entities.Recommendation{
+			{UserID: 123, AudioContentID: 1, Score: 3.0, Reason: entities.ReasonPopular},
+			{UserID: 123, AudioContentID: 2, Score: 2.0, Reason: entities.ReasonPopular},
+			{UserID: 123, AudioContentID: 3, Score: 1.0, Reason: entities.ReasonPopular},
+		},
+	}
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			"recommendations:user:123": cachedOutput,
+		},
+	}
+	mockUser := &mockUserRepository{
+		user: &entities.User{ID: 123, Email: "test@example.com"},
+	}
+	continueListening := &mockContinueListeningService{
+		items: []*entities.ContinueListeningItem{
+			{Content: &entities.AudioContent{ID: 2, Duration: 600}, PlayedSeconds: 300, Progress: 0.5},
+		},
+	}
+
+	usecase := NewGetRecommendationsUsecase(
+		&mockRecommendationBlender{},
+		&mockRecommendationReranker{},
+		continueListening,
+		mockCache,
+		mockUser,
+	)
+
+	tests := []struct {
+		name     string
+		include  bool
+		expected []int
+	}{
+		{name: "指定なしではそのまま", include: false, expected: []int{1, 2, 3}},
+		{name: "先頭に挿入して重複を除く", include: true, expected: []int{2, 1, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			output, err := usecase.Execute(context.Background(), &GetRecommendationsInput{
+				UserID:                   123,
+				Limit:                    3,
+				IncludeContinueListening: tt.include,
+			})
+			if err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if len(output.Recommendations) != len(tt.expected) {
+				t.Fatalf("%d件のレコメンドを期待しましたが、%d件を取得しました", len(tt.expected), len(output.Recommendations))
+			}
+			for i, rec := range output.Recommendations {
+				if rec.AudioContentID != tt.expected[i] {
+					t.Errorf("インデックス %d: コンテンツ %d を期待しましたが、%d を取得しました", i, tt.expected[i], rec.AudioContentID)
+				}
+			}
+			if tt.include && output.Recommendations[0].Reason != entities.ReasonContinueListening {
+				t.Errorf("理由 %s を期待しましたが、%s を取得しました", entities.ReasonContinueListening, output.Recommendations[0].Reason)
+			}
+		})
+	}
+
+	// キャッシュされた結果は変更しない
+	if len(cachedOutput.Recommendations) != 3 || cachedOutput.Recommendations[0].AudioContentID != 1 {
+		t.Error("キャッシュされた結果が変更されています")
+	}
+}
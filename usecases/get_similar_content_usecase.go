@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// SimilarContentServiceInterface 類似コンテンツ検索サービスのインターフェース
+type SimilarContentServiceInterface interface {
+	FindSimilar(ctx context.Context, seed *entities.AudioContent, excludeIDs []int, limit int) ([]*entities.SimilarContent, error)
+}
+
+// GetSimilarContentInput 類似コンテンツ取得の入力
+type GetSimilarContentInput struct {
+	ContentID int
+	Limit     int
+}
+
+// SimilarContentItem 類似コンテンツの項目
+type SimilarContentItem struct {
+	AudioContentID int                             `json:"audioContentId"`
+	Title          string                          `json:"title"`
+	CategoryID     int                             `json:"categoryId"`
+	AuthorID       int                             `json:"authorId"`
+	Duration       int                             `json:"duration"`
+	Score          float64                         `json:"score"`
+	Reasons        []entities.RecommendationReason `json:"reasons"`
+}
+
+// GetSimilarContentOutput 類似コンテンツ取得の出力
+type GetSimilarContentOutput struct {
+	AudioContentID int                   `json:"audioContentId"`
+	Items          []*SimilarContentItem `json:"items"`
+	Timestamp      int64                 `json:"timestamp"`
+}
+
+// GetSimilarContentUsecase 類似コンテンツ（もっと聴く）取得ユースケース
+type GetSimilarContentUsecase struct {
+	similarContentService SimilarContentServiceInterface
+	audioContentRepo      repositories.AudioContentRepository
+	cacheRepo             repositories.CacheRepository
+}
+
+// NewGetSimilarContentUsecase コンストラクタ
+func NewGetSimilarContentUsecase(
+	similarContentService SimilarContentServiceInterface,
+	audioContentRepo repositories.AudioContentRepository,
+	cacheRepo repositories.CacheRepository,
+) *GetSimilarContentUsecase {
+	return &GetSimilarContentUsecase{
+		similarContentService: similarContentService,
+		audioContentRepo:      audioContentRepo,
+		cacheRepo:             cacheRepo,
+	}
+}
+
+// Execute ユースケース実行
+func (uc *GetSimilarContentUsecase) Execute(ctx context.Context, input *GetSimilarContentInput) (*GetSimilarContentOutput, error) {
+	if input.ContentID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidContentID, input.ContentID)
+	}
+
+	if input.Limit <= 0 {
+		input.Limit = 10 // デフォルト値
+	}
+
+	cacheKey := fmt.Sprintf("similar:content:%d:%d", input.ContentID, input.Limit)
+	var cachedOutput GetSimilarContentOutput
+	if err := uc.cacheRepo.Get(ctx, cacheKey, &cachedOutput); err == nil {
+		return &cachedOutput, nil
+	}
+
+	seed, err := uc.audioContentRepo.GetByID(ctx, input.ContentID)
+	if err != nil {
+		return nil, fmt.Errorf("コンテンツ情報の取得に失敗しました: %w", err)
+	}
+	if seed == nil {
+		return nil, fmt.Errorf("%w: %d", ErrContentNotFound, input.ContentID)
+	}
+
+	similar, err := uc.similarContentService.FindSimilar(ctx, seed, nil, input.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("類似コンテンツの取得に失敗しました: %w", err)
+	}
+
+	items := make([]*SimilarContentItem, 0, len(similar))
+	for _, s := range similar {
+		items = append(items, &SimilarContentItem{
+			AudioContentID: s.Content.ID,
+			Title:          s.Content.Title,
+			CategoryID:     s.Content.CategoryID,
+			AuthorID:       s.Content.AuthorID,
+			Duration:       s.Content.Duration,
+			Score:          s.Score,
+			Reasons:        s.Reasons,
+		})
+	}
+
+	output := &GetSimilarContentOutput{
+		AudioContentID: seed.ID,
+		Items:          items,
+		Timestamp:      time.Now().Unix(),
+	}
+
+	// キャッシュに保存
+	if err := uc.cacheRepo.Set(ctx, cacheKey, output, time.Hour); err != nil {
+		// ログ出力のみで続行
+	}
+
+	return output, nil
+}
@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// TrendingServiceInterface 急上昇コンテンツ検索サービスのインターフェース
+type TrendingServiceInterface interface {
+	Find(ctx context.Context, limit int) ([]*entities.TrendingItem, error)
+}
+
+// GetTrendingInput 急上昇取得の入力
+type GetTrendingInput struct {
+	Limit int
+}
+
+// TrendingItem 急上昇の項目
+type TrendingItem struct {
+	AudioContentID int     `json:"audioContentId"`
+	Title          string  `json:"title"`
+	CategoryID     int     `json:"categoryId"`
+	AuthorID       int     `json:"authorId"`
+	Duration       int     `json:"duration"`
+	RecentPlays    int     `json:"recentPlays"`
+	BaselinePlays  int     `json:"baselinePlays"`
+	Score          float64 `json:"score"`
+}
+
+// GetTrendingOutput 急上昇取得の出力
+type GetTrendingOutput struct {
+	Items     []*TrendingItem `json:"items"`
+	Timestamp int64           `json:"timestamp"`
+}
+
+// GetTrendingUsecase 急上昇コンテンツ取得ユースケース
+type GetTrendingUsecase struct {
+	trending  TrendingServiceInterface
+	cacheRepo repositories.CacheRepository
+}
+
+// NewGetTrendingUsecase コンストラクタ
+func NewGetTrendingUsecase(
+	trending TrendingServiceInterface,
+	cacheRepo repositories.CacheRepository,
+) *GetTrendingUsecase {
+	return &GetTrendingUsecase{
+		trending:  trending,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Execute ユースケース実行（全ユーザー共通のため短時間キャッシュ）
+func (uc *GetTrendingUsecase) Execute(ctx context.Context, input *GetTrendingInput) (*GetTrendingOutput, error) {
+	if input.Limit <= 0 {
+		input.Limit = 20 // デフォルト値
+	}
+
+	cacheKey := fmt.Sprintf("trending:%d", input.Limit)
+	var cachedOutput GetTrendingOutput
+	if err := uc.cacheRepo.Get(ctx, cacheKey, &cachedOutput); err == nil {
+		return &cachedOutput, nil
+	}
+
+	items, err := uc.trending.Find(ctx, input.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("急上昇コンテンツの取得に失敗しました: %w", err)
+	}
+
+	output := &GetTrendingOutput{
+		Items:     make([]*TrendingItem, 0, len(items)),
+		Timestamp: time.Now().Unix(),
+	}
+	for _, item := range items {
+		output.Items = append(output.Items, &TrendingItem{
+			AudioContentID: item.Content.ID,
+			Title:          item.Content.Title,
+			CategoryID:     item.Content.CategoryID,
+			AuthorID:       item.Content.AuthorID,
+			Duration:       item.Content.Duration,
+			RecentPlays:    item.RecentPlays,
+			BaselinePlays:  item.BaselinePlays,
+			Score:          item.Score,
+		})
+	}
+
+	// キャッシュに保存
+	if err := uc.cacheRepo.Set(ctx, cacheKey, output, 10*time.Minute); err != nil {
+		// ログ出力のみで続行
+	}
+
+	return output, nil
+}
@@ -0,0 +1,85 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// RecordDismissalInput 「興味なし」記録の入力
+type RecordDismissalInput struct {
+	UserID     int
+	TargetType string
+	TargetID   int
+}
+
+// RecordDismissalOutput 「興味なし」記録の出力
+type RecordDismissalOutput struct {
+	UserID     int    `json:"userId"`
+	TargetType string `json:"targetType"`
+	TargetID   int    `json:"targetId"`
+	Timestamp  int64  `json:"timestamp"`
+}
+
+// RecordDismissalUsecase コンテンツ・作者・カテゴリへの「興味なし」を記録するユースケース
+type RecordDismissalUsecase struct {
+	dismissalRepo repositories.DismissalRepository
+	userRepo      repositories.UserRepository
+	cacheRepo     repositories.CacheRepository
+}
+
+// NewRecordDismissalUsecase コンストラクタ
+func NewRecordDismissalUsecase(
+	dismissalRepo repositories.DismissalRepository,
+	userRepo repositories.UserRepository,
+	cacheRepo repositories.CacheRepository,
+) *RecordDismissalUsecase {
+	return &RecordDismissalUsecase{
+		dismissalRepo: dismissalRepo,
+		userRepo:      userRepo,
+		cacheRepo:     cacheRepo,
+	}
+}
+
+// Execute ユースケース実行
+func (uc *RecordDismissalUsecase) Execute(ctx context.Context, input *RecordDismissalInput) (*RecordDismissalOutput, error) {
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
+	}
+
+	dismissal := &entities.Dismissal{
+		UserID:     input.UserID,
+		TargetType: entities.DismissalTargetType(input.TargetType),
+		TargetID:   input.TargetID,
+		CreatedAt:  time.Now(),
+	}
+	if !dismissal.IsValid() {
+		return nil, fmt.Errorf("%w: %s %d", ErrInvalidDismissal, input.TargetType, input.TargetID)
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, input.UserID)
+	}
+
+	if err := uc.dismissalRepo.Save(ctx, dismissal); err != nil {
+		return nil, fmt.Errorf("フィードバックの保存に失敗しました: %w", err)
+	}
+
+	// すぐに反映させるためキャッシュを削除
+	if err := uc.cacheRepo.Delete(ctx, recommendationCacheKey(input.UserID)); err != nil {
+		// ログ出力のみで続行
+	}
+
+	return &RecordDismissalOutput{
+		UserID:     dismissal.UserID,
+		TargetType: string(dismissal.TargetType),
+		TargetID:   dismissal.TargetID,
+		Timestamp:  dismissal.CreatedAt.Unix(),
+	}, nil
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+type mockDismissalRepository struct {
+	saved []*entities.Dismissal
+	err   error
+}
+
+func (m *mockDismissalRepository) Save(ctx context.Context, dismissal *entities.Dismissal) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saved = append(m.saved, dismissal)
+	return nil
+}
+
+func (m *mockDismissalRepository) GetByUser(ctx context.Context, userID int) ([]*entities.Dismissal, error) {
+	return m.saved, m.err
+}
+
+func TestRecordDismissalUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *entities.User
+		input       *RecordDismissalInput
+		expectedErr error
+	}{
+		{
+			name:  "作者を興味なしに",
+			user:  &entities.User{ID: 1, Email: "test@example.com"},
+			input: &RecordDismissalInput{UserID: 1, TargetType: "author", TargetID: 100},
+		},
+		{
+			name:        "未知の対象種別",
+			user:        &entities.User{ID: 1, Email: "test@example.com"},
+			input:       &RecordDismissalInput{UserID: 1, TargetType: "playlist", TargetID: 1},
+			expectedErr: ErrInvalidDismissal,
+		},
+		{
+			name:        "無効な対象ID",
+			user:        &entities.User{ID: 1, Email: "test@example.com"},
+			input:       &RecordDismissalInput{UserID: 1, TargetType: "content", TargetID: 0},
+			expectedErr: ErrInvalidDismissal,
+		},
+		{
+			name:        "ユーザーが存在しない",
+			user:        nil,
+			input:       &RecordDismissalInput{UserID: 1, TargetType: "content", TargetID: 1},
+			expectedErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dismissalRepo := &mockDismissalRepository{}
+			cacheRepo := &mockCacheRepository{
+				data: map[string]interface{}{
+					"recommendations:user:1": &GetRecommendationsOutput{UserID: 1},
+				},
+			}
+			usecase := NewRecordDismissalUsecase(dismissalRepo, &mockUserRepository{user: tt.user}, cacheRepo)
+
+			_, err := usecase.Execute(context.Background(), tt.input)
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("%vを期待しましたが、%vを取得しました", tt.expectedErr, err)
+				}
+				if len(dismissalRepo.saved) != 0 {
+					t.Error("エラー時に保存されないことを期待しました")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if len(dismissalRepo.saved) != 1 || dismissalRepo.saved[0].TargetType != entities.DismissalTargetAuthor {
+				t.Errorf("作者のフィードバック1件の保存を期待しましたが、%vを取得しました", dismissalRepo.saved)
+			}
+			if _, exists := cacheRepo.data["recommendations:user:1"]; exists {
+				t.Error("レコメンドのキャッシュが削除されることを期待しました")
+			}
+		})
+	}
+}
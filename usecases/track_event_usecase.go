@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// TrackEventInput イベント追跡の入力
+type TrackEventInput struct {
+	UserID         int
+	AudioContentID int
+	EventType      string
+	Duration       int
+	OccurredAt     time.Time
+}
+
+// TrackEventOutput イベント追跡の出力
+type TrackEventOutput struct {
+	UserID         int    `json:"userId"`
+	AudioContentID int    `json:"audioContentId"`
+	EventType      string `json:"eventType"`
+	Recorded       bool   `json:"recorded"`
+	Timestamp      int64  `json:"timestamp"`
+}
+
+// TrackEventUsecase イベント追跡ユースケース
+type TrackEventUsecase struct {
+	playbackRepo repositories.PlaybackRepository
+	ratingRepo   repositories.UserRatingRepository
+	cacheRepo    repositories.CacheRepository
+}
+
+// NewTrackEventUsecase コンストラクタ
+func NewTrackEventUsecase(
+	playbackRepo repositories.PlaybackRepository,
+	ratingRepo repositories.UserRatingRepository,
+	cacheRepo repositories.CacheRepository,
+) *TrackEventUsecase {
+	return &TrackEventUsecase{
+		playbackRepo: playbackRepo,
+		ratingRepo:   ratingRepo,
+		cacheRepo:    cacheRepo,
+	}
+}
+
+// Execute ユースケース実行
+func (uc *TrackEventUsecase) Execute(ctx context.Context, input *TrackEventInput) (*TrackEventOutput, error) {
+	event, err := toUserEvent(input)
+	if err != nil {
+		return nil, err
+	}
+
+	// 再生系イベントはListenHistoryに記録（一時停止・再開などは同じ再生の行を更新）
+	recorded := false
+	if history := event.ToPlaybackHistory(); history != nil {
+		if err := uc.playbackRepo.SavePlayback(ctx, history); err != nil {
+			return nil, fmt.Errorf("再生履歴の保存に失敗しました: %w", err)
+		}
+		recorded = true
+	}
+
+	// いいね・よくないは明示的な評価として記録
+	if rating := event.ToRating(); rating != nil {
+		if err := uc.ratingRepo.Save(ctx, rating); err != nil {
+			return nil, fmt.Errorf("評価の保存に失敗しました: %w", err)
+		}
+		recorded = true
+	}
+
+	// 行動が変わったのでレコメンドキャッシュを即時破棄
+	if err := uc.cacheRepo.Delete(ctx, recommendationCacheKey(event.UserID)); err != nil {
+		// ログ出力のみで続行
+	}
+
+	return &TrackEventOutput{
+		UserID:         event.UserID,
+		AudioContentID: event.AudioContentID,
+		EventType:      string(event.EventType),
+		Recorded:       recorded,
+		Timestamp:      event.OccurredAt.Unix(),
+	}, nil
+}
+
+// toUserEvent 入力を検証してドメインイベントに変換
+func toUserEvent(input *TrackEventInput) (*entities.UserEvent, error) {
+	occurredAt := input.OccurredAt
+	if occurredAt.IsZero() {
+		occurredAt = time.Now()
+	}
+
+	event := &entities.UserEvent{
+		UserID:         input.UserID,
+		AudioContentID: input.AudioContentID,
+		EventType:      entities.EventType(input.EventType),
+		Duration:       input.Duration,
+		OccurredAt:     occurredAt,
+	}
+
+	if !event.IsValid() {
+		return nil, fmt.Errorf("%w: userId=%d, audioContentId=%d, eventType=%q, duration=%d",
+			ErrInvalidEvent, input.UserID, input.AudioContentID, input.EventType, input.Duration)
+	}
+
+	return event, nil
+}
@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+	"time"
+)
+
+type mockPlaybackRepository struct {
+	saved []*entities.PlaybackHistory
+	err   error
+}
+
+func (m *mockPlaybackRepository) GetUserHistory(ctx context.Context, userID int, limit int) ([]*entities.PlaybackHistory, error) {
+	return []*entities.PlaybackHistory{}, nil
+}
+
+func (m *mockPlaybackRepository) SavePlayback(ctx context.Context, history *entities.PlaybackHistory) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saved = append(m.saved, history)
+	return nil
+}
+
+func (m *mockPlaybackRepository) SavePlaybacks(ctx context.Context, histories []*entities.PlaybackHistory) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saved = append(m.saved, histories...)
+	return nil
+}
+
+func (m *mockPlaybackRepository) GetRecentPlaybacks(ctx context.Context, userID int, days int) ([]*entities.PlaybackHistory, error) {
+	return []*entities.PlaybackHistory{}, nil
+}
+
+func (m *mockPlaybackRepository) GetPlaybacksWithinDays(ctx context.Context, days int) ([]*entities.PlaybackHistory, error) {
+	return []*entities.PlaybackHistory{}, nil
+}
+
+func (m *mockPlaybackRepository) GetCoListenedContent(ctx context.Context, contentID int, days int, limit int) ([]*entities.CoListenedContent, error) {
+	return []*entities.CoListenedContent{}, nil
+}
+
+func (m *mockPlaybackRepository) GetPlayVelocities(ctx context.Context, recentWindow, baselineWindow time.Duration, minRecentPlays int) ([]*entities.PlayVelocity, error) {
+	return nil, nil
+}
+
+type mockUserRatingRepository struct {
+	saved []*entities.UserRating
+	err   error
+}
+
+func (m *mockUserRatingRepository) Save(ctx context.Context, rating *entities.UserRating) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saved = append(m.saved, rating)
+	return nil
+}
+
+func (m *mockUserRatingRepository) GetByUser(ctx context.Context, userID int, limit int) ([]*entities.UserRating, error) {
+	return m.saved, nil
+}
+
+func TestTrackEventUsecase_Execute_RecordsPlaybackAndInvalidatesCache(t *testing.T) {
+	mockPlayback := &mockPlaybackRepository{}
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			"recommendations:user:1": &GetRecommendationsOutput{UserID: 1},
+		},
+	}
+
+	usecase := NewTrackEventUsecase(mockPlayback, &mockUserRatingRepository{}, mockCache)
+
+	output, err := usecase.Execute(context.Background(), &TrackEventInput{
+		UserID:         1,
+		AudioContentID: 123,
+		EventType:      "complete",
+		Duration:       180,
+	})
+
+	if err != nil {
+		t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+	}
+
+	if !output.Recorded {
+		t.Error("再生履歴が記録されることを期待しました")
+	}
+
+	if len(mockPlayback.saved) != 1 || !mockPlayback.saved[0].Completed {
+		t.Errorf("完了済みの再生履歴1件を期待しましたが、%v を取得しました", mockPlayback.saved)
+	}
+
+	if _, exists := mockCache.data["recommendations:user:1"]; exists {
+		t.Error("レコメンドキャッシュが破棄されていません")
+	}
+}
+
+func TestTrackEventUsecase_Execute_RatingIsNotRecordedAsPlayback(t *testing.T) {
+	tests := []struct {
+		eventType string
+		expected  int
+	}{
+		{eventType: "like", expected: entities.RatingLike},
+		{eventType: "dislike", expected: entities.RatingDislike},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.eventType, func(t *testing.T) {
+			mockPlayback := &mockPlaybackRepository{}
+			mockRating := &mockUserRatingRepository{}
+			usecase := NewTrackEventUsecase(mockPlayback, mockRating, &mockCacheRepository{})
+
+			output, err := usecase.Execute(context.Background(), &TrackEventInput{
+				UserID:         1,
+				AudioContentID: 123,
+				EventType:      tt.eventType,
+			})
+
+			if err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if len(mockPlayback.saved) != 0 {
+				t.Errorf("%sイベントは再生履歴に記録されないことを期待しました", tt.eventType)
+			}
+			if !output.Recorded || len(mockRating.saved) != 1 || mockRating.saved[0].Rating != tt.expected {
+				t.Errorf("評価 %d の記録を期待しましたが、%v を取得しました", tt.expected, mockRating.saved)
+			}
+		})
+	}
+}
+
+func TestTrackEventUsecase_Execute_InvalidEvent(t *testing.T) {
+	tests := []struct {
+		name  string
+		input *TrackEventInput
+	}{
+		{
+			name:  "無効なユーザーID",
+			input: &TrackEventInput{UserID: 0, AudioContentID: 123, EventType: "play"},
+		},
+		{
+			name:  "無効なオーディオコンテンツID",
+			input: &TrackEventInput{UserID: 1, AudioContentID: 0, EventType: "play"},
+		},
+		{
+			name:  "未知のイベント種別",
+			input: &TrackEventInput{UserID: 1, AudioContentID: 123, EventType: "rewind"},
+		},
+		{
+			name:  "負の再生時間",
+			input: &TrackEventInput{UserID: 1, AudioContentID: 123, EventType: "play", Duration: -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := NewTrackEventUsecase(&mockPlaybackRepository{}, &mockUserRatingRepository{}, &mockCacheRepository{})
+
+			_, err := usecase.Execute(context.Background(), tt.input)
+			if !errors.Is(err, ErrInvalidEvent) {
+				t.Errorf("ErrInvalidEventを期待しましたが、%vを取得しました", err)
+			}
+		})
+	}
+}
+
+func TestTrackEventUsecase_Execute_SaveFailure(t *testing.T) {
+	mockPlayback := &mockPlaybackRepository{err: errors.New("DBエラー")}
+	usecase := NewTrackEventUsecase(mockPlayback, &mockUserRatingRepository{}, &mockCacheRepository{})
+
+	_, err := usecase.Execute(context.Background(), &TrackEventInput{
+		UserID:         1,
+		AudioContentID: 123,
+		EventType:      "play",
+		Duration:       30,
+	})
+
+	if err == nil || errors.Is(err, ErrInvalidEvent) {
+		t.Errorf("保存失敗のエラーを期待しましたが、%vを取得しました", err)
+	}
+}
@@ -0,0 +1,178 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/entities"
+	"mimiru-ai/domain/repositories"
+	"time"
+)
+
+// 冪等性キーの保持期間（オフライン端末の再送を想定）
+const eventIdempotencyTTL = 7 * 24 * time.Hour
+
+// BulkEventStatus 一括取り込みにおける各行の処理結果
+type BulkEventStatus string
+
+const (
+	BulkEventAccepted  BulkEventStatus = "accepted"
+	BulkEventDuplicate BulkEventStatus = "duplicate"
+	BulkEventRejected  BulkEventStatus = "rejected"
+	BulkEventFailed    BulkEventStatus = "failed"
+)
+
+// BulkEventItem 一括取り込みの1行分の入力
+type BulkEventItem struct {
+	Line    int
+	EventID string
+	Event   *TrackEventInput
+}
+
+// BulkEventResult 一括取り込みの1行分の結果
+type BulkEventResult struct {
+	Line    int             `json:"line"`
+	EventID string          `json:"eventId,omitempty"`
+	Status  BulkEventStatus `json:"status"`
+	Error   string          `json:"error,omitempty"`
+}
+
+// TrackEventsBulkOutput 一括取り込みの出力
+type TrackEventsBulkOutput struct {
+	Accepted   int                `json:"accepted"`
+	Duplicates int                `json:"duplicates"`
+	Rejected   int                `json:"rejected"`
+	Failed     int                `json:"failed"`
+	Results    []*BulkEventResult `json:"results"`
+}
+
+// Add 結果を追加して件数を集計
+func (o *TrackEventsBulkOutput) Add(result *BulkEventResult) {
+	switch result.Status {
+	case BulkEventAccepted:
+		o.Accepted++
+	case BulkEventDuplicate:
+		o.Duplicates++
+	case BulkEventRejected:
+		o.Rejected++
+	case BulkEventFailed:
+		o.Failed++
+	}
+	o.Results = append(o.Results, result)
+}
+
+// TrackEventsBulkUsecase イベント一括取り込みユースケース
+type TrackEventsBulkUsecase struct {
+	playbackRepo repositories.PlaybackRepository
+	ratingRepo   repositories.UserRatingRepository
+	cacheRepo    repositories.CacheRepository
+}
+
+// NewTrackEventsBulkUsecase コンストラクタ
+func NewTrackEventsBulkUsecase(
+	playbackRepo repositories.PlaybackRepository,
+	ratingRepo repositories.UserRatingRepository,
+	cacheRepo repositories.CacheRepository,
+) *TrackEventsBulkUsecase {
+	return &TrackEventsBulkUsecase{
+		playbackRepo: playbackRepo,
+		ratingRepo:   ratingRepo,
+		cacheRepo:    cacheRepo,
+	}
+}
+
+// eventIdempotencyKey イベントIDの冪等性キー
+func eventIdempotencyKey(userID int, eventID string) string {
+	return fmt.Sprintf("events:processed:%d:%s", userID, eventID)
+}
+
+// Execute ユースケース実行（結果は入力と同じ順序で返す）
+func (uc *TrackEventsBulkUsecase) Execute(ctx context.Context, items []*BulkEventItem) *TrackEventsBulkOutput {
+	results := make([]*BulkEventResult, len(items))
+	claimedKeys := make(map[int]string)
+	var histories []*entities.PlaybackHistory
+	var historyIndexes []int
+	touchedUsers := make(map[int]bool)
+	seen := make(map[string]bool)
+
+	for i, item := range items {
+		result := &BulkEventResult{Line: item.Line, EventID: item.EventID}
+		results[i] = result
+
+		if item.EventID == "" {
+			result.Status = BulkEventRejected
+			result.Error = "eventIdが必要です"
+			continue
+		}
+
+		event, err := toUserEvent(item.Event)
+		if err != nil {
+			result.Status = BulkEventRejected
+			result.Error = err.Error()
+			continue
+		}
+
+		// 同一バッチ内の重複
+		key := eventIdempotencyKey(event.UserID, item.EventID)
+		if seen[key] {
+			result.Status = BulkEventDuplicate
+			continue
+		}
+		seen[key] = true
+
+		// 過去のバッチで処理済みかを確認しつつ処理権を確保
+		claimed, err := uc.cacheRepo.SetIfNotExists(ctx, key, event.OccurredAt.Unix(), eventIdempotencyTTL)
+		if err != nil {
+			result.Status = BulkEventFailed
+			result.Error = fmt.Sprintf("冪等性キーの確認に失敗しました: %v", err)
+			continue
+		}
+		if !claimed {
+			result.Status = BulkEventDuplicate
+			continue
+		}
+		claimedKeys[i] = key
+
+		// 評価は行ごとに保存（失敗した行は再送で取り込めるよう処理権を解放）
+		if rating := event.ToRating(); rating != nil {
+			if err := uc.ratingRepo.Save(ctx, rating); err != nil {
+				if err := uc.cacheRepo.Delete(ctx, key); err != nil {
+					// ログ出力のみで続行
+				}
+				result.Status = BulkEventFailed
+				result.Error = fmt.Sprintf("評価の保存に失敗しました: %v", err)
+				continue
+			}
+		}
+
+		result.Status = BulkEventAccepted
+		touchedUsers[event.UserID] = true
+		if history := event.ToPlaybackHistory(); history != nil {
+			histories = append(histories, history)
+			historyIndexes = append(historyIndexes, i)
+		}
+	}
+
+	if err := uc.playbackRepo.SavePlaybacks(ctx, histories); err != nil {
+		// 再送で取り込めるよう処理権を解放
+		for _, i := range historyIndexes {
+			if err := uc.cacheRepo.Delete(ctx, claimedKeys[i]); err != nil {
+				// ログ出力のみで続行
+			}
+			results[i].Status = BulkEventFailed
+			results[i].Error = fmt.Sprintf("再生履歴の保存に失敗しました: %v", err)
+		}
+	}
+
+	for userID := range touchedUsers {
+		if err := uc.cacheRepo.Delete(ctx, recommendationCacheKey(userID)); err != nil {
+			// ログ出力のみで続行
+		}
+	}
+
+	output := &TrackEventsBulkOutput{Results: make([]*BulkEventResult, 0, len(results))}
+	for _, result := range results {
+		output.Add(result)
+	}
+
+	return output
+}
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestTrackEventsBulkUsecase_Execute_DeduplicatesAndReportsPerLine(t *testing.T) {
+	mockPlayback := &mockPlaybackRepository{}
+	mockCache := &mockCacheRepository{
+		data: map[string]interface{}{
+			// 前回のアップロードで処理済みのイベント
+			"events:processed:1:ev-0": int64(0),
+		},
+	}
+
+	mockRating := &mockUserRatingRepository{}
+	usecase := NewTrackEventsBulkUsecase(mockPlayback, mockRating, mockCache)
+
+	items := []*BulkEventItem{
+		{Line: 1, EventID: "ev-0", Event: &TrackEventInput{UserID: 1, AudioContentID: 10, EventType: "play", Duration: 30}},
+		{Line: 2, EventID: "ev-1", Event: &TrackEventInput{UserID: 1, AudioContentID: 11, EventType: "complete", Duration: 300}},
+		{Line: 3, EventID: "ev-1", Event: &TrackEventInput{UserID: 1, AudioContentID: 11, EventType: "complete", Duration: 300}},
+		{Line: 4, EventID: "ev-2", Event: &TrackEventInput{UserID: 1, AudioContentID: 12, EventType: "like"}},
+		{Line: 5, EventID: "ev-3", Event: &TrackEventInput{UserID: 1, AudioContentID: 13, EventType: "rewind"}},
+		{Line: 6, EventID: "", Event: &TrackEventInput{UserID: 1, AudioContentID: 14, EventType: "play"}},
+	}
+
+	output := usecase.Execute(context.Background(), items)
+
+	expected := []BulkEventStatus{
+		BulkEventDuplicate,
+		BulkEventAccepted,
+		BulkEventDuplicate,
+		BulkEventAccepted,
+		BulkEventRejected,
+		BulkEventRejected,
+	}
+	for i, result := range output.Results {
+		if result.Line != items[i].Line {
+			t.Errorf("インデックス %d: 行番号 %d を期待しましたが、%d を取得しました", i, items[i].Line, result.Line)
+		}
+		if result.Status != expected[i] {
+			t.Errorf("行 %d: ステータス %s を期待しましたが、%s を取得しました", result.Line, expected[i], result.Status)
+		}
+	}
+
+	if output.Accepted != 2 || output.Duplicates != 2 || output.Rejected != 2 {
+		t.Errorf("集計が正しくありません: %+v", output)
+	}
+
+	// 再生系イベントのみ一括保存される
+	if len(mockPlayback.saved) != 1 {
+		t.Errorf("1件の再生履歴保存を期待しましたが、%d件を取得しました", len(mockPlayback.saved))
+	}
+
+	// likeは評価として保存される
+	if len(mockRating.saved) != 1 || mockRating.saved[0].AudioContentID != 12 {
+		t.Errorf("コンテンツ12の評価1件を期待しましたが、%v を取得しました", mockRating.saved)
+	}
+}
+
+func TestTrackEventsBulkUsecase_Execute_ReleasesKeysOnSaveFailure(t *testing.T) {
+	mockPlayback := &mockPlaybackRepository{err: errors.New("DBエラー")}
+	mockCache := &mockCacheRepository{}
+
+	usecase := NewTrackEventsBulkUsecase(mockPlayback, &mockUserRatingRepository{}, mockCache)
+
+	items := []*BulkEventItem{
+		{Line: 1, EventID: "ev-1", Event: &TrackEventInput{UserID: 1, AudioContentID: 10, EventType: "play", Duration: 30}},
+	}
+
+	output := usecase.Execute(context.Background(), items)
+
+	if output.Failed != 1 {
+		t.Errorf("1件の失敗を期待しましたが、%d件を取得しました", output.Failed)
+	}
+
+	// 再送時に取り込めるよう冪等性キーは解放されている
+	if _, exists := mockCache.data["events:processed:1:ev-1"]; exists {
+		t.Error("保存失敗時に冪等性キーが解放されていません")
+	}
+}
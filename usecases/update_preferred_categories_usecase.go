@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"mimiru-ai/domain/repositories"
+)
+
+// MaxPreferredCategories オンボーディングで選択できるカテゴリの上限
+const MaxPreferredCategories = 20
+
+// UpdatePreferredCategoriesInput 好みのカテゴリ更新の入力
+type UpdatePreferredCategoriesInput struct {
+	UserID      int
+	CategoryIDs []int
+}
+
+// UpdatePreferredCategoriesOutput 好みのカテゴリ更新の出力
+type UpdatePreferredCategoriesOutput struct {
+	UserID      int   `json:"userId"`
+	CategoryIDs []int `json:"categoryIds"`
+}
+
+// UpdatePreferredCategoriesUsecase オンボーディングで選択したカテゴリを保存するユースケース
+type UpdatePreferredCategoriesUsecase struct {
+	userRepo  repositories.UserRepository
+	cacheRepo repositories.CacheRepository
+}
+
+// NewUpdatePreferredCategoriesUsecase コンストラクタ
+func NewUpdatePreferredCategoriesUsecase(
+	userRepo repositories.UserRepository,
+	cacheRepo repositories.CacheRepository,
+) *UpdatePreferredCategoriesUsecase {
+	return &UpdatePreferredCategoriesUsecase{
+		userRepo:  userRepo,
+		cacheRepo: cacheRepo,
+	}
+}
+
+// Execute ユースケース実行（選択済みのカテゴリは置き換え）
+func (uc *UpdatePreferredCategoriesUsecase) Execute(ctx context.Context, input *UpdatePreferredCategoriesInput) (*UpdatePreferredCategoriesOutput, error) {
+	if input.UserID <= 0 {
+		return nil, fmt.Errorf("%w: %d", ErrInvalidUserID, input.UserID)
+	}
+
+	categoryIDs, err := normalizeCategoryIDs(input.CategoryIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.GetByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("ユーザー情報の取得に失敗しました: %w", err)
+	}
+	if user == nil {
+		return nil, fmt.Errorf("%w: %d", ErrUserNotFound, input.UserID)
+	}
+
+	if err := uc.userRepo.SavePreferredCategories(ctx, input.UserID, categoryIDs); err != nil {
+		return nil, fmt.Errorf("好みのカテゴリの保存に失敗しました: %w", err)
+	}
+
+	// 次回のレコメンドに反映させる
+	if err := uc.cacheRepo.Delete(ctx, recommendationCacheKey(input.UserID)); err != nil {
+		// ログ出力のみで続行
+	}
+
+	return &UpdatePreferredCategoriesOutput{
+		UserID:      input.UserID,
+		CategoryIDs: categoryIDs,
+	}, nil
+}
+
+// normalizeCategoryIDs カテゴリIDを検証し、選択順を保って重複を除く
+func normalizeCategoryIDs(categoryIDs []int) ([]int, error) {
+	seen := make(map[int]bool, len(categoryIDs))
+	normalized := make([]int, 0, len(categoryIDs))
+	for _, id := range categoryIDs {
+		if id <= 0 {
+			return nil, fmt.Errorf("%w: %d", ErrInvalidCategoryID, id)
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		normalized = append(normalized, id)
+	}
+
+	if len(normalized) > MaxPreferredCategories {
+		return nil, fmt.Errorf("%w: 最大%d件まで選択できます", ErrInvalidCategoryID, MaxPreferredCategories)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"mimiru-ai/domain/entities"
+	"testing"
+)
+
+func TestUpdatePreferredCategoriesUsecase_Execute(t *testing.T) {
+	tests := []struct {
+		name        string
+		user        *entities.User
+		categoryIDs []int
+		expected    []int
+		expectedErr error
+	}{
+		{
+			name:        "重複を除いて選択順で保存",
+			user:        &entities.User{ID: 1, Email: "test@example.com"},
+			categoryIDs: []int{3, 1, 3, 2},
+			expected:    []int{3, 1, 2},
+		},
+		{
+			name:        "空にするとクリア",
+			user:        &entities.User{ID: 1, Email: "test@example.com"},
+			categoryIDs: []int{},
+			expected:    []int{},
+		},
+		{
+			name:        "無効なカテゴリID",
+			user:        &entities.User{ID: 1, Email: "test@example.com"},
+			categoryIDs: []int{1, 0},
+			expectedErr: ErrInvalidCategoryID,
+		},
+		{
+			name:        "ユーザーが存在しない",
+			user:        nil,
+			categoryIDs: []int{1},
+			expectedErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			userRepo := &mockUserRepository{user: tt.user}
+			cacheRepo := &mockCacheRepository{
+				data: map[string]interface{}{
+					"recommendations:user:1": &GetRecommendationsOutput{UserID: 1},
+				},
+			}
+			usecase := NewUpdatePreferredCategoriesUsecase(userRepo, cacheRepo)
+
+			output, err := usecase.Execute(context.Background(), &UpdatePreferredCategoriesInput{
+				UserID:      1,
+				CategoryIDs: tt.categoryIDs,
+			})
+
+			if tt.expectedErr != nil {
+				if !errors.Is(err, tt.expectedErr) {
+					t.Errorf("%vを期待しましたが、%vを取得しました", tt.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("エラーがないことを期待しましたが、%vを取得しました", err)
+			}
+
+			if len(output.CategoryIDs) != len(tt.expected) || len(userRepo.savedCategories) != len(tt.expected) {
+				t.Fatalf("%v を期待しましたが、%v（保存: %v）を取得しました", tt.expected, output.CategoryIDs, userRepo.savedCategories)
+			}
+			for i, id := range tt.expected {
+				if userRepo.savedCategories[i] != id {
+					t.Errorf("インデックス %d: カテゴリ %d を期待しましたが、%d を取得しました", i, id, userRepo.savedCategories[i])
+				}
+			}
+
+			if _, exists := cacheRepo.data["recommendations:user:1"]; exists {
+				t.Error("レコメンドのキャッシュが削除されることを期待しました")
+			}
+		})
+	}
+}